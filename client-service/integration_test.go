@@ -1,15 +1,15 @@
 package main
 
 import (
-	"context"
-	"log"
-	"net"
-	"testing"
 	"client-service/pb"
+	"context"
+	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
-	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/test/bufconn"
+	"log"
+	"net"
+	"testing"
 )
 
 const bufSize = 1024 * 1024
@@ -19,7 +19,7 @@ var lis *bufconn.Listener
 func init() {
 	lis = bufconn.Listen(bufSize)
 	s := grpc.NewServer()
-	pb.RegisterClientServiceServer(s, NewServer())
+	pb.RegisterClientServiceServer(s, newMemoryService(context.Background()))
 	reflection.Register(s)
 	go func() {
 		if err := s.Serve(lis); err != nil {
@@ -47,7 +47,8 @@ func TestRegisterClientIntegration(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.True(t, resp.Success)
-	assert.Contains(t, resp.Message, "Client registered with ID")
+	assert.Equal(t, "Client registered successfully", resp.Message)
+	assert.NotEmpty(t, resp.TicketNumber)
 }
 
 func TestGetClientStatusIntegration(t *testing.T) {
@@ -62,16 +63,26 @@ func TestGetClientStatusIntegration(t *testing.T) {
 		QueueId: 1,
 		Name:    "Dias Ermek",
 	}
-	_, err = client.RegisterClient(ctx, registerReq)
+	firstResp, err := client.RegisterClient(ctx, registerReq)
+	assert.NoError(t, err)
+
+	// Register a second client behind the first one
+	secondResp, err := client.RegisterClient(ctx, &pb.RegisterClientRequest{
+		QueueId: 1,
+		Name:    "Ernar Asherbekov",
+	})
 	assert.NoError(t, err)
 
 	// Now get the client status
 	statusReq := &pb.GetClientStatusRequest{
-		QueueId: 1,
+		ClientId: secondResp.ClientId,
 	}
 	statusResp, err := client.GetClientStatus(ctx, statusReq)
 	assert.NoError(t, err)
 	assert.NotNil(t, statusResp)
-	assert.Equal(t, []string{"Dias Ermek"}, statusResp.Clients)
-	assert.Equal(t, "Clients retrieved successfully", statusResp.Message)
+	assert.Equal(t, "Ernar Asherbekov", statusResp.Client.Name)
+	assert.Equal(t, secondResp.TicketNumber, statusResp.Client.TicketNumber)
+	assert.NotEqual(t, firstResp.TicketNumber, secondResp.TicketNumber)
+	assert.Equal(t, statusResp.ClientsBefore+1, statusResp.PlaceInQueue)
+	assert.Greater(t, statusResp.ClientsBefore, int32(0))
 }
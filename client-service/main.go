@@ -2,94 +2,125 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
+	"client-service/migrations"
 	"client-service/models"
 	pb "client-service/pb"
+	"client-service/repository"
+	clientserver "client-service/server"
+	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"queue-management-system/migrate"
 )
 
-// Server struct to implement the gRPC server methods
-type server struct {
-	pb.UnimplementedClientServiceServer
-	clients map[int32]*models.Client
-	queues  map[int32]*models.Queue
-}
-
-// NewServer creates a new server instance with initialized data
-func NewServer() *server {
-	return &server{
-		clients: make(map[int32]*models.Client),
-		queues:  make(map[int32]*models.Queue),
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
 	}
-}
 
-// RegisterClient registers a new client in the specified queue
-func (s *server) RegisterClient(ctx context.Context, req *pb.RegisterClientRequest) (*pb.RegisterClientResponse, error) {
-	clientID := int32(len(s.clients) + 1)
-	client := &models.Client{
-		ID:      clientID,
-		QueueID: req.GetQueueId(),
-		Name:    req.GetName(),
+	lis, err := net.Listen("tcp", ":50051")
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
 	}
-	s.clients[clientID] = client
 
-	// Assuming the queue exists. You can add more error handling here.
-	if _, exists := s.queues[req.GetQueueId()]; !exists {
-		return &pb.RegisterClientResponse{
-			Success: false,
-			Message: "Queue not found",
-		}, nil
+	// ctx stops the position updates on shutdown, which also ends the
+	// WatchPosition streams the graceful stop would otherwise wait for.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	grpcServer := grpc.NewServer()
+	// Without a database clients are kept in memory, which is enough for
+	// local development.
+	if dsn := os.Getenv("DATABASE_URL"); dsn != "" {
+		pb.RegisterClientServiceServer(grpcServer, newPostgresService(ctx, dsn))
+	} else {
+		pb.RegisterClientServiceServer(grpcServer, newMemoryService(ctx))
 	}
 
-	return &pb.RegisterClientResponse{
-		Success: true,
-		Message: fmt.Sprintf("Client registered with ID %d", clientID),
-	}, nil
-}
+	// Register reflection service on gRPC server.
+	reflection.Register(grpcServer)
 
-// GetClientStatus retrieves the status of clients in the specified queue
-func (s *server) GetClientStatus(ctx context.Context, req *pb.GetClientStatusRequest) (*pb.GetClientStatusResponse, error) {
-	queueID := req.GetQueueId()
-	var clientNames []string
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		log.Println("shutting down")
+		cancel()
+		grpcServer.GracefulStop()
+	}()
 
-	for _, client := range s.clients {
-		if client.QueueID == queueID {
-			clientNames = append(clientNames, client.Name)
-		}
+	log.Printf("Server listening on port %v", lis.Addr())
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
 	}
+}
 
-	if len(clientNames) == 0 {
-		return &pb.GetClientStatusResponse{
-			Clients: clientNames,
-			Message: "No clients found in the specified queue",
-		}, nil
+// newPostgresService returns a service backed by the database at dsn. Its
+// position updates follow the clients_changed notifications of the database,
+// so changes made by other services reach watchers too.
+func newPostgresService(ctx context.Context, dsn string) *clientserver.ClientServiceServer {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
 	}
-
-	return &pb.GetClientStatusResponse{
-		Clients: clientNames,
-		Message: "Clients retrieved successfully",
-	}, nil
+	if os.Getenv("MIGRATE_ON_START") == "true" {
+		if err := newMigrator(db).Up(ctx); err != nil {
+			log.Fatalf("failed to migrate the database: %v", err)
+		}
+	}
+	if err := migrate.Require(ctx, db, "queue-management", migrations.QueueManagementVersion); err != nil {
+		log.Fatalf("database is not ready: %v", err)
+	}
+	clients := repository.NewPostgresClientRepository(db)
+	hub := clientserver.NewPositionHub(clients)
+	go hub.Run(ctx)
+	go hub.Listen(ctx, dsn)
+	return clientserver.NewClientService(repository.NewPostgresQueueRepository(db), clients, hub)
 }
 
-func main() {
-	lis, err := net.Listen("tcp", ":50051")
+// runMigrate carries out the migrate subcommand against DATABASE_URL.
+func runMigrate(args []string) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		log.Fatal("migrate: DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		log.Fatalf("failed to connect to database: %v", err)
 	}
+	defer db.Close()
+	if err := migrate.Run(context.Background(), newMigrator(db), args, os.Stdout); err != nil {
+		log.Fatalf("migrate: %v", err)
+	}
+}
 
-	grpcServer := grpc.NewServer()
-	s := NewServer()
-	pb.RegisterClientServiceServer(grpcServer, s)
-
-	// Register reflection service on gRPC server.
-	reflection.Register(grpcServer)
+// newMigrator returns the migrator of the embedded client migrations.
+func newMigrator(db *sql.DB) *migrate.Migrator {
+	m, err := migrate.New(db, "client", migrations.FS)
+	if err != nil {
+		log.Fatalf("invalid migrations: %v", err)
+	}
+	return m
+}
 
-	log.Printf("Server listening on port %v", lis.Addr())
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+// newMemoryService returns a service keeping clients in memory, with a
+// single queue with ID 1 to register them in.
+func newMemoryService(ctx context.Context) *clientserver.ClientServiceServer {
+	queues := repository.NewMemoryQueueRepository()
+	queue := &models.Queue{Name: "Default", TicketPadding: models.DefaultTicketPadding, TicketReset: models.TicketResetDaily}
+	if err := queues.Create(ctx, queue); err != nil {
+		log.Fatalf("failed to create the default queue: %v", err)
 	}
+	clients := repository.NewMemoryClientRepository(queues)
+	hub := clientserver.NewPositionHub(clients)
+	go hub.Run(ctx)
+	return clientserver.NewClientService(queues, clients, hub)
 }
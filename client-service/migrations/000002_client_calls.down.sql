@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_clients_waiting;
+
+ALTER TABLE clients
+    DROP COLUMN IF EXISTS counter_id,
+    DROP COLUMN IF EXISTS called_at;
@@ -0,0 +1,7 @@
+-- Track which counter called a client and when
+ALTER TABLE clients
+    ADD COLUMN called_at TIMESTAMP,
+    ADD COLUMN counter_id INT;
+
+-- Index for picking the next waiting client of a queue
+CREATE INDEX idx_clients_waiting ON clients (queue_id, created_at) WHERE called_at IS NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_clients_waiting;
+CREATE INDEX idx_clients_waiting ON clients (queue_id, created_at) WHERE called_at IS NULL;
+
+ALTER TABLE clients
+    DROP CONSTRAINT IF EXISTS clients_status_check,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS no_show_at,
+    DROP COLUMN IF EXISTS served_at,
+    DROP COLUMN IF EXISTS serving_started_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,16 @@
+-- Lifecycle state of a client: waiting -> called -> serving -> served,
+-- or leaving early as no_show / cancelled
+ALTER TABLE clients
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    ADD COLUMN serving_started_at TIMESTAMP,
+    ADD COLUMN served_at TIMESTAMP,
+    ADD COLUMN no_show_at TIMESTAMP,
+    ADD COLUMN cancelled_at TIMESTAMP,
+    ADD CONSTRAINT clients_status_check
+        CHECK (status IN ('waiting', 'called', 'serving', 'served', 'no_show', 'cancelled'));
+
+UPDATE clients SET status = 'called' WHERE called_at IS NOT NULL;
+
+-- Waiting clients are now identified by status rather than called_at
+DROP INDEX IF EXISTS idx_clients_waiting;
+CREATE INDEX idx_clients_waiting ON clients (queue_id, created_at) WHERE status = 'waiting';
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS clients_changed ON clients;
+DROP FUNCTION IF EXISTS notify_clients_changed();
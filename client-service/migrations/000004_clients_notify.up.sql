@@ -0,0 +1,16 @@
+-- Notify listeners with the queue ID whenever a client row changes
+CREATE OR REPLACE FUNCTION notify_clients_changed() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        PERFORM pg_notify('clients_changed', OLD.queue_id::text);
+    END IF;
+    IF TG_OP <> 'DELETE' AND (TG_OP = 'INSERT' OR NEW.queue_id IS DISTINCT FROM OLD.queue_id) THEN
+        PERFORM pg_notify('clients_changed', NEW.queue_id::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER clients_changed
+    AFTER INSERT OR UPDATE OR DELETE ON clients
+    FOR EACH ROW EXECUTE FUNCTION notify_clients_changed();
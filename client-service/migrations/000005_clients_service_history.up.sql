@@ -0,0 +1,2 @@
+-- Index for reading the most recent service durations of a queue
+CREATE INDEX idx_clients_served ON clients (queue_id, served_at DESC) WHERE status = 'served';
@@ -0,0 +1,3 @@
+ALTER TABLE clients
+    DROP CONSTRAINT IF EXISTS clients_priority_check,
+    DROP COLUMN IF EXISTS priority;
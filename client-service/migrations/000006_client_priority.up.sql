@@ -0,0 +1,4 @@
+-- Priority tier of a client: 0 is the regular tier, higher tiers are served first
+ALTER TABLE clients
+    ADD COLUMN priority INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT clients_priority_check CHECK (priority >= 0);
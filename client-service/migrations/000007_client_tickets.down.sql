@@ -0,0 +1,3 @@
+ALTER TABLE clients DROP COLUMN IF EXISTS ticket_number;
+
+DROP TABLE IF EXISTS ticket_sequences;
@@ -0,0 +1,12 @@
+-- Last ticket number handed out per queue and period. The period is the
+-- date for queues resetting daily and 'all' for queues that never reset.
+-- Allocating updates the row in the registering transaction, so numbers
+-- are gapless: a failed registration rolls its number back.
+CREATE TABLE ticket_sequences (
+    queue_id INT NOT NULL,
+    period VARCHAR(10) NOT NULL,
+    last_number INT NOT NULL,
+    PRIMARY KEY (queue_id, period)
+);
+
+ALTER TABLE clients ADD COLUMN ticket_number VARCHAR(20) NOT NULL DEFAULT '';
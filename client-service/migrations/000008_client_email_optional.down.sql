@@ -0,0 +1,3 @@
+ALTER TABLE clients
+    ALTER COLUMN email DROP DEFAULT,
+    ADD CONSTRAINT clients_email_key UNIQUE (email);
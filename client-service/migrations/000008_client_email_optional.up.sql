@@ -0,0 +1,4 @@
+-- Email is optional, and the same person may queue more than once
+ALTER TABLE clients
+    DROP CONSTRAINT IF EXISTS clients_email_key,
+    ALTER COLUMN email SET DEFAULT '';
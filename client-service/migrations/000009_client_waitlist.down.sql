@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_clients_waitlisted;
+
+-- Without a waitlist the clients still on it cannot be kept.
+UPDATE clients SET status = 'cancelled', cancelled_at = NOW() WHERE status = 'waitlisted';
+
+ALTER TABLE clients
+    DROP CONSTRAINT IF EXISTS clients_status_check,
+    ADD CONSTRAINT clients_status_check
+        CHECK (status IN ('waiting', 'called', 'serving', 'served', 'no_show', 'cancelled'));
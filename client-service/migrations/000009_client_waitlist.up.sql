@@ -0,0 +1,9 @@
+-- Clients registering in a full queue with a waitlist stay outside it as
+-- 'waitlisted' until a place frees up.
+ALTER TABLE clients
+    DROP CONSTRAINT clients_status_check,
+    ADD CONSTRAINT clients_status_check
+        CHECK (status IN ('waitlisted', 'waiting', 'called', 'serving', 'served', 'no_show', 'cancelled'));
+
+-- Waitlisted clients are promoted in the order they registered.
+CREATE INDEX idx_clients_waitlisted ON clients (queue_id, created_at) WHERE status = 'waitlisted';
@@ -0,0 +1,15 @@
+// Package migrations embeds the SQL migrations of the service so the binary
+// can apply them itself. They create the clients; the queue management
+// service migrations build on them and add the queues.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
+
+// QueueManagementVersion is the version of the queue management service
+// migrations the service reads: the queues with their schedule, capacity and
+// state, the queue_positions function ordering waiting clients and the
+// counters. Raise it along with changes to them that the service follows.
+const QueueManagementVersion = 12
@@ -1,13 +1,122 @@
 package models
 
+import (
+	"fmt"
+	"time"
+
+	"queue-management-system/schedule"
+)
+
 type Queue struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID            int32       `json:"id"`
+	Name          string      `json:"name"`
+	TicketPrefix  string      `json:"ticket_prefix"`
+	TicketPadding int         `json:"ticket_padding"`
+	TicketReset   TicketReset `json:"ticket_reset"`
+	// Schedule is when clients can register. Queues without one are always
+	// open.
+	Schedule *schedule.Schedule `json:"schedule,omitempty"`
+	// MaxSize caps the waiting clients, zero for no cap. OverflowPolicy
+	// decides what happens to clients registering while the queue is full.
+	MaxSize         int32          `json:"max_size"`
+	OverflowPolicy  OverflowPolicy `json:"overflow_policy"`
+	OverflowQueueID int32          `json:"overflow_queue_id,omitempty"`
+	// State is set by operators; closed and draining queues take no new
+	// clients.
+	State QueueState `json:"state"`
+}
+
+// QueueState is whether a queue takes new clients and calls the ones it has.
+type QueueState string
+
+const (
+	QueueOpen     QueueState = "open"
+	QueuePaused   QueueState = "paused"
+	QueueClosed   QueueState = "closed"
+	QueueDraining QueueState = "draining"
+)
+
+// AcceptsClients reports whether clients can register in a queue in state s.
+func (s QueueState) AcceptsClients() bool {
+	return s != QueueClosed && s != QueueDraining
+}
+
+// IsOpen reports whether clients can register in the queue at t.
+func (q *Queue) IsOpen(t time.Time) bool {
+	return q.Schedule == nil || q.Schedule.IsOpen(t)
+}
+
+// OverflowPolicy is what a full queue does with clients registering in it.
+type OverflowPolicy string
+
+const (
+	// OverflowReject turns clients away.
+	OverflowReject OverflowPolicy = "reject"
+	// OverflowRedirect registers clients in the queue's overflow queue.
+	OverflowRedirect OverflowPolicy = "redirect"
+	// OverflowWaitlist registers clients as waitlisted until a place frees
+	// up.
+	OverflowWaitlist OverflowPolicy = "waitlist"
+)
+
+// TicketReset is how often the ticket numbers of a queue start over.
+type TicketReset string
+
+const (
+	TicketResetDaily TicketReset = "daily"
+	TicketResetNever TicketReset = "never"
+)
+
+// DefaultTicketPadding is the number of digits tickets are padded to when a
+// queue does not configure it.
+const DefaultTicketPadding = 3
+
+// Period returns the numbering period a ticket issued at t belongs to. Each
+// period has its own sequence, starting at 1.
+func (r TicketReset) Period(t time.Time) string {
+	if r == TicketResetNever {
+		return "all"
+	}
+	return t.Format("2006-01-02")
 }
 
+// FormatTicketNumber renders the n-th ticket of a queue, e.g. "A-042" for
+// prefix "A" and padding 3, or "042" when the queue has no prefix.
+func FormatTicketNumber(prefix string, padding, n int) string {
+	if padding <= 0 {
+		padding = DefaultTicketPadding
+	}
+	if prefix == "" {
+		return fmt.Sprintf("%0*d", padding, n)
+	}
+	return fmt.Sprintf("%s-%0*d", prefix, padding, n)
+}
+
+// ClientStatus is the lifecycle state of a client within a queue.
+type ClientStatus string
+
+const (
+	// StatusWaitlisted clients registered while their queue was full and
+	// are not in line yet.
+	StatusWaitlisted ClientStatus = "waitlisted"
+	StatusWaiting    ClientStatus = "waiting"
+	StatusCalled     ClientStatus = "called"
+	StatusServing    ClientStatus = "serving"
+	StatusServed     ClientStatus = "served"
+	StatusNoShow     ClientStatus = "no_show"
+	StatusCancelled  ClientStatus = "cancelled"
+)
+
+// ActiveStatuses are the states in which a client still occupies the queue.
+var ActiveStatuses = []ClientStatus{StatusWaiting, StatusCalled, StatusServing}
+
 type Client struct {
-	ID      int32  `json:"id"`
-	QueueID int32  `json:"queue_id"`
-	Name    string `json:"name"`
-	Email   string `json:"email"`
+	ID           int32        `json:"id"`
+	QueueID      int32        `json:"queue_id"`
+	Name         string       `json:"name"`
+	Email        string       `json:"email"`
+	TicketNumber string       `json:"ticket_number"`
+	Status       ClientStatus `json:"status"`
+	Priority     int32        `json:"priority"`
+	JoinedAt     time.Time    `json:"joined_at"`
 }
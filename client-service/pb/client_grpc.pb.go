@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion8
 const (
 	ClientService_RegisterClient_FullMethodName  = "/client.ClientService/RegisterClient"
 	ClientService_GetClientStatus_FullMethodName = "/client.ClientService/GetClientStatus"
+	ClientService_WatchPosition_FullMethodName   = "/client.ClientService/WatchPosition"
 )
 
 // ClientServiceClient is the client API for ClientService service.
@@ -29,6 +30,7 @@ const (
 type ClientServiceClient interface {
 	RegisterClient(ctx context.Context, in *RegisterClientRequest, opts ...grpc.CallOption) (*RegisterClientResponse, error)
 	GetClientStatus(ctx context.Context, in *GetClientStatusRequest, opts ...grpc.CallOption) (*GetClientStatusResponse, error)
+	WatchPosition(ctx context.Context, in *WatchPositionRequest, opts ...grpc.CallOption) (ClientService_WatchPositionClient, error)
 }
 
 type clientServiceClient struct {
@@ -59,12 +61,46 @@ func (c *clientServiceClient) GetClientStatus(ctx context.Context, in *GetClient
 	return out, nil
 }
 
+func (c *clientServiceClient) WatchPosition(ctx context.Context, in *WatchPositionRequest, opts ...grpc.CallOption) (ClientService_WatchPositionClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ClientService_ServiceDesc.Streams[0], ClientService_WatchPosition_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clientServiceWatchPositionClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ClientService_WatchPositionClient interface {
+	Recv() (*PositionUpdate, error)
+	grpc.ClientStream
+}
+
+type clientServiceWatchPositionClient struct {
+	grpc.ClientStream
+}
+
+func (x *clientServiceWatchPositionClient) Recv() (*PositionUpdate, error) {
+	m := new(PositionUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ClientServiceServer is the server API for ClientService service.
 // All implementations must embed UnimplementedClientServiceServer
 // for forward compatibility
 type ClientServiceServer interface {
 	RegisterClient(context.Context, *RegisterClientRequest) (*RegisterClientResponse, error)
 	GetClientStatus(context.Context, *GetClientStatusRequest) (*GetClientStatusResponse, error)
+	WatchPosition(*WatchPositionRequest, ClientService_WatchPositionServer) error
 	mustEmbedUnimplementedClientServiceServer()
 }
 
@@ -78,6 +114,9 @@ func (UnimplementedClientServiceServer) RegisterClient(context.Context, *Registe
 func (UnimplementedClientServiceServer) GetClientStatus(context.Context, *GetClientStatusRequest) (*GetClientStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClientStatus not implemented")
 }
+func (UnimplementedClientServiceServer) WatchPosition(*WatchPositionRequest, ClientService_WatchPositionServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPosition not implemented")
+}
 func (UnimplementedClientServiceServer) mustEmbedUnimplementedClientServiceServer() {}
 
 // UnsafeClientServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -127,6 +166,27 @@ func _ClientService_GetClientStatus_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientService_WatchPosition_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPositionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClientServiceServer).WatchPosition(m, &clientServiceWatchPositionServer{ServerStream: stream})
+}
+
+type ClientService_WatchPositionServer interface {
+	Send(*PositionUpdate) error
+	grpc.ServerStream
+}
+
+type clientServiceWatchPositionServer struct {
+	grpc.ServerStream
+}
+
+func (x *clientServiceWatchPositionServer) Send(m *PositionUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ClientService_ServiceDesc is the grpc.ServiceDesc for ClientService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -143,6 +203,12 @@ var ClientService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ClientService_GetClientStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPosition",
+			Handler:       _ClientService_WatchPosition_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "client.proto",
 }
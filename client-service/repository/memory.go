@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"client-service/models"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryQueueRepository keeps queues in memory.
+type MemoryQueueRepository struct {
+	mu     sync.Mutex
+	queues map[int32]*models.Queue
+	nextID int32
+}
+
+func NewMemoryQueueRepository() *MemoryQueueRepository {
+	return &MemoryQueueRepository{queues: make(map[int32]*models.Queue)}
+}
+
+func (r *MemoryQueueRepository) Create(ctx context.Context, queue *models.Queue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	queue.ID = r.nextID
+	if queue.OverflowPolicy == "" {
+		queue.OverflowPolicy = models.OverflowReject
+	}
+	if queue.State == "" {
+		queue.State = models.QueueOpen
+	}
+	stored := *queue
+	r.queues[queue.ID] = &stored
+	return nil
+}
+
+func (r *MemoryQueueRepository) Get(ctx context.Context, id int32) (*models.Queue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *queue
+	return &copied, nil
+}
+
+// Delete removes a queue, standing in for the queue management service that
+// deletes queues in production.
+func (r *MemoryQueueRepository) Delete(ctx context.Context, id int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.queues[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.queues, id)
+	return nil
+}
+
+// SetState changes the state of a queue, standing in for the queue
+// management service that changes it in production.
+func (r *MemoryQueueRepository) SetState(ctx context.Context, id int32, state models.QueueState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[id]
+	if !ok {
+		return ErrNotFound
+	}
+	queue.State = state
+	return nil
+}
+
+// SetTicketPrefix changes the ticket prefix of a queue, standing in for the
+// queue management service that changes it in production.
+func (r *MemoryQueueRepository) SetTicketPrefix(ctx context.Context, id int32, prefix string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[id]
+	if !ok {
+		return ErrNotFound
+	}
+	queue.TicketPrefix = prefix
+	return nil
+}
+
+// MemoryClientRepository keeps clients in memory, registering them in the
+// queues of the queue repository it was created with. Queues are ordered
+// strictly: higher priority tiers first, first come first served within a
+// tier. Nobody calls clients registered in memory, so queues report no
+// service history.
+type MemoryClientRepository struct {
+	queues  *MemoryQueueRepository
+	mu      sync.Mutex
+	clients map[int32]*models.Client
+	tickets map[ticketPeriod]int
+	nextID  int32
+}
+
+// ticketPeriod identifies a ticket sequence: a queue within one period.
+type ticketPeriod struct {
+	queueID int32
+	period  string
+}
+
+func NewMemoryClientRepository(queues *MemoryQueueRepository) *MemoryClientRepository {
+	return &MemoryClientRepository{
+		queues:  queues,
+		clients: make(map[int32]*models.Client),
+		tickets: make(map[ticketPeriod]int),
+	}
+}
+
+func (r *MemoryClientRepository) Register(ctx context.Context, client *models.Client, queue *models.Queue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The caller's copy of the queue may predate its deletion or a change
+	// of its state or ticket settings.
+	current, err := r.queues.Get(ctx, queue.ID)
+	if err != nil {
+		return err
+	}
+	if !current.State.AcceptsClients() {
+		return ErrQueueClosed
+	}
+	clientStatus := models.StatusWaiting
+	if current.MaxSize > 0 && int32(len(r.waiting(queue.ID))) >= current.MaxSize {
+		if current.OverflowPolicy != models.OverflowWaitlist {
+			return ErrQueueFull
+		}
+		clientStatus = models.StatusWaitlisted
+	}
+
+	now := time.Now()
+	key := ticketPeriod{queueID: queue.ID, period: current.TicketReset.Period(now)}
+	r.tickets[key]++
+
+	r.nextID++
+	client.ID = r.nextID
+	client.QueueID = queue.ID
+	client.TicketNumber = models.FormatTicketNumber(current.TicketPrefix, current.TicketPadding, r.tickets[key])
+	client.Status = clientStatus
+	client.JoinedAt = now
+	stored := *client
+	r.clients[client.ID] = &stored
+	return nil
+}
+
+func (r *MemoryClientRepository) Get(ctx context.Context, id int32) (*models.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	client, ok := r.clients[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *client
+	return &copied, nil
+}
+
+func (r *MemoryClientRepository) Place(ctx context.Context, queueID, clientID int32) (int32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, client := range r.waiting(queueID) {
+		if client.ID == clientID {
+			return int32(i + 1), nil
+		}
+	}
+	return 0, nil
+}
+
+func (r *MemoryClientRepository) ActivePositions(ctx context.Context, queueID int32) ([]Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var positions []Position
+	for i, client := range r.waiting(queueID) {
+		positions = append(positions, Position{ClientID: client.ID, Status: client.Status, Place: int32(i + 1)})
+	}
+	for _, client := range r.clients {
+		if client.QueueID == queueID && (client.Status == models.StatusCalled || client.Status == models.StatusServing) {
+			positions = append(positions, Position{ClientID: client.ID, Status: client.Status})
+		}
+	}
+	return positions, nil
+}
+
+func (r *MemoryClientRepository) ServiceDurations(ctx context.Context, queueID int32, limit int) ([]float64, error) {
+	return nil, nil
+}
+
+func (r *MemoryClientRepository) OpenCounters(ctx context.Context, queueID int32) (int32, error) {
+	return 0, nil
+}
+
+// waiting returns the waiting clients of a queue in the order they will be
+// called. r.mu must be held.
+func (r *MemoryClientRepository) waiting(queueID int32) []*models.Client {
+	var waiting []*models.Client
+	for _, client := range r.clients {
+		if client.QueueID == queueID && client.Status == models.StatusWaiting {
+			waiting = append(waiting, client)
+		}
+	}
+	sort.Slice(waiting, func(i, j int) bool {
+		a, b := waiting[i], waiting[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		// IDs are handed out in registration order.
+		return a.ID < b.ID
+	})
+	return waiting
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"client-service/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"queue-management-system/schedule"
+)
+
+// PostgresQueueRepository reads queues from the queues table.
+type PostgresQueueRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresQueueRepository(db *sql.DB) *PostgresQueueRepository {
+	return &PostgresQueueRepository{db: db}
+}
+
+func (r *PostgresQueueRepository) Create(ctx context.Context, queue *models.Queue) error {
+	if queue.OverflowPolicy == "" {
+		queue.OverflowPolicy = models.OverflowReject
+	}
+	if queue.State == "" {
+		queue.State = models.QueueOpen
+	}
+	var scheduleParam interface{}
+	if queue.Schedule != nil {
+		data, err := json.Marshal(queue.Schedule)
+		if err != nil {
+			return err
+		}
+		scheduleParam = string(data)
+	}
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO queues (name, ticket_prefix, ticket_padding, ticket_reset, schedule, max_size, overflow_policy, overflow_queue_id, state)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, 0), $9)
+		RETURNING id`, queue.Name, queue.TicketPrefix, queue.TicketPadding, queue.TicketReset, scheduleParam,
+		queue.MaxSize, queue.OverflowPolicy, queue.OverflowQueueID, queue.State).Scan(&queue.ID)
+}
+
+func (r *PostgresQueueRepository) Get(ctx context.Context, id int32) (*models.Queue, error) {
+	var queue models.Queue
+	var scheduleData []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, name, ticket_prefix, ticket_padding, ticket_reset, schedule, max_size, overflow_policy, COALESCE(overflow_queue_id, 0), state
+		FROM queues WHERE id = $1 AND deleted_at IS NULL`, id).
+		Scan(&queue.ID, &queue.Name, &queue.TicketPrefix, &queue.TicketPadding, &queue.TicketReset, &scheduleData,
+			&queue.MaxSize, &queue.OverflowPolicy, &queue.OverflowQueueID, &queue.State)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if scheduleData != nil {
+		queue.Schedule = &schedule.Schedule{}
+		if err := json.Unmarshal(scheduleData, queue.Schedule); err != nil {
+			return nil, fmt.Errorf("queue %d: invalid schedule: %w", queue.ID, err)
+		}
+	}
+	return &queue, nil
+}
+
+// PostgresClientRepository stores clients in the clients table and reads
+// their places from the queue_positions function, the order the dequeue
+// path uses. The function belongs to the queue management migrations; this
+// service relies only on it returning (client_id, place) for the waiting
+// clients of a queue, as of migrations.QueueManagementVersion.
+type PostgresClientRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresClientRepository(db *sql.DB) *PostgresClientRepository {
+	return &PostgresClientRepository{db: db}
+}
+
+// Register allocates the ticket number in the registering transaction. The
+// sequence row stays locked until it ends, so concurrent registrations queue
+// up behind each other and a rolled back registration leaves no gap.
+func (r *PostgresClientRepository) Register(ctx context.Context, client *models.Client, queue *models.Queue) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, clientStatus, err := r.admit(ctx, tx, queue.ID)
+	if err != nil {
+		return err
+	}
+
+	var number int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO ticket_sequences (queue_id, period, last_number) VALUES ($1, $2, 1)
+		ON CONFLICT (queue_id, period) DO UPDATE SET last_number = ticket_sequences.last_number + 1
+		RETURNING last_number`, queue.ID, current.TicketReset.Period(time.Now())).Scan(&number)
+	if err != nil {
+		return err
+	}
+	ticket := models.FormatTicketNumber(current.TicketPrefix, current.TicketPadding, number)
+
+	var id int32
+	err = tx.QueryRowContext(ctx, "INSERT INTO clients (queue_id, name, email, priority, ticket_number, status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
+		queue.ID, client.Name, client.Email, client.Priority, ticket, clientStatus).Scan(&id)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	client.ID = id
+	client.QueueID = queue.ID
+	client.TicketNumber = ticket
+	client.Status = clientStatus
+	return nil
+}
+
+// admit returns the queue a client registers in and the status the client
+// starts in. It locks the queue row for the rest of tx, so a registration and
+// a Delete or state change of the queue, which lock the row too, each see the
+// other's outcome, and registrations and the promotion of waitlisted clients
+// see each other's counts. The queue is read again under the lock because the
+// caller's copy may predate such a change, or one of its ticket settings.
+func (r *PostgresClientRepository) admit(ctx context.Context, tx *sql.Tx, queueID int32) (*models.Queue, models.ClientStatus, error) {
+	queue := &models.Queue{ID: queueID}
+	var waiting int32
+	var deletedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, `
+		SELECT deleted_at, state, max_size, overflow_policy, ticket_prefix, ticket_padding, ticket_reset
+		FROM queues WHERE id = $1 FOR NO KEY UPDATE`, queueID).
+		Scan(&deletedAt, &queue.State, &queue.MaxSize, &queue.OverflowPolicy, &queue.TicketPrefix, &queue.TicketPadding, &queue.TicketReset)
+	if err == sql.ErrNoRows || deletedAt.Valid {
+		return nil, "", ErrNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if !queue.State.AcceptsClients() {
+		return nil, "", ErrQueueClosed
+	}
+	if queue.MaxSize == 0 {
+		return queue, models.StatusWaiting, nil
+	}
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM clients WHERE queue_id = $1 AND status = 'waiting'", queueID).Scan(&waiting)
+	if err != nil {
+		return nil, "", err
+	}
+	switch {
+	case waiting < queue.MaxSize:
+		return queue, models.StatusWaiting, nil
+	case queue.OverflowPolicy == models.OverflowWaitlist:
+		return queue, models.StatusWaitlisted, nil
+	}
+	return nil, "", ErrQueueFull
+}
+
+func (r *PostgresClientRepository) Get(ctx context.Context, id int32) (*models.Client, error) {
+	var client models.Client
+	err := r.db.QueryRowContext(ctx, "SELECT id, queue_id, name, email, ticket_number, status, priority, created_at FROM clients WHERE id = $1", id).
+		Scan(&client.ID, &client.QueueID, &client.Name, &client.Email, &client.TicketNumber, &client.Status, &client.Priority, &client.JoinedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *PostgresClientRepository) Place(ctx context.Context, queueID, clientID int32) (int32, error) {
+	var place int32
+	err := r.db.QueryRowContext(ctx, "SELECT place FROM queue_positions($1) WHERE client_id = $2", queueID, clientID).Scan(&place)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return place, err
+}
+
+func (r *PostgresClientRepository) ActivePositions(ctx context.Context, queueID int32) ([]Position, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.id, c.status, COALESCE(p.place, 0) FROM clients c
+		LEFT JOIN queue_positions($1) p ON p.client_id = c.id
+		WHERE c.queue_id = $1 AND c.status IN ('waiting', 'called', 'serving')`, queueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []Position
+	for rows.Next() {
+		var p Position
+		if err := rows.Scan(&p.ClientID, &p.Status, &p.Place); err != nil {
+			return nil, err
+		}
+		positions = append(positions, p)
+	}
+	return positions, rows.Err()
+}
+
+func (r *PostgresClientRepository) ServiceDurations(ctx context.Context, queueID int32, limit int) ([]float64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT EXTRACT(EPOCH FROM served_at - serving_started_at) FROM (
+			SELECT served_at, serving_started_at FROM clients
+			WHERE queue_id = $1 AND status = 'served' AND serving_started_at IS NOT NULL
+			UNION ALL
+			-- Served clients are archived after a while but still count.
+			SELECT served_at, serving_started_at FROM clients_archive
+			WHERE queue_id = $1 AND status = 'served' AND serving_started_at IS NOT NULL
+		) served
+		ORDER BY served_at DESC
+		LIMIT $2`, queueID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var newestFirst []float64
+	for rows.Next() {
+		var seconds float64
+		if err := rows.Scan(&seconds); err != nil {
+			return nil, err
+		}
+		newestFirst = append(newestFirst, seconds)
+	}
+	return newestFirst, rows.Err()
+}
+
+func (r *PostgresClientRepository) OpenCounters(ctx context.Context, queueID int32) (int32, error) {
+	var counters int32
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM counters c
+		JOIN counter_queues cq ON cq.counter_id = c.id
+		WHERE cq.queue_id = $1 AND c.open`, queueID).Scan(&counters)
+	return counters, err
+}
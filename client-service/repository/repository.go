@@ -0,0 +1,63 @@
+// Package repository stores the queues clients register in and the clients
+// themselves. ClientServiceServer only talks to the QueueRepository and
+// ClientRepository interfaces; PostgreSQL backs them in production and the
+// in-memory implementations serve tests and local development.
+package repository
+
+import (
+	"client-service/models"
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when the requested queue or client does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrQueueFull is returned by Register when the queue has no room for
+// another waiting client and does not keep a waitlist.
+var ErrQueueFull = errors.New("queue is full")
+
+// ErrQueueClosed is returned by Register when the state of the queue keeps
+// it from taking new clients.
+var ErrQueueClosed = errors.New("queue is closed")
+
+// Position is where an active client stands in its queue.
+type Position struct {
+	ClientID int32
+	Status   models.ClientStatus
+	// Place is the 1-based place of a waiting client and zero for clients
+	// that were already called.
+	Place int32
+}
+
+type QueueRepository interface {
+	// Create stores a new queue and sets its ID.
+	Create(ctx context.Context, queue *models.Queue) error
+	// Get returns a queue clients can register in; deleted queues are
+	// not found.
+	Get(ctx context.Context, id int32) (*models.Queue, error)
+}
+
+type ClientRepository interface {
+	// Register adds a waiting client to queue, sets its ID and hands it the
+	// queue's next ticket number. Numbers are gapless: a registration that
+	// fails does not use one up. The queue is read again as the client is
+	// added, so a stale copy does not let a client in: it returns
+	// ErrNotFound when the queue has been deleted and ErrQueueClosed when its
+	// state no longer takes clients. When the queue holds MaxSize waiting
+	// clients the client is waitlisted if the queue keeps a waitlist, and
+	// ErrQueueFull is returned otherwise.
+	Register(ctx context.Context, client *models.Client, queue *models.Queue) error
+	Get(ctx context.Context, id int32) (*models.Client, error)
+	// Place returns the 1-based place of a waiting client, or zero when the
+	// client is not waiting.
+	Place(ctx context.Context, queueID, clientID int32) (int32, error)
+	// ActivePositions returns the position of every client still occupying
+	// a queue.
+	ActivePositions(ctx context.Context, queueID int32) ([]Position, error)
+	// ServiceDurations returns how long the last limit services of a queue
+	// took in seconds, newest first.
+	ServiceDurations(ctx context.Context, queueID int32, limit int) ([]float64, error)
+	// OpenCounters counts the open counters assigned to a queue.
+	OpenCounters(ctx context.Context, queueID int32) (int32, error)
+}
@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"client-service/migrations"
+	"client-service/models"
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"queue-management-system/migrate"
+)
+
+// newRepositories returns empty repositories of one backend.
+type newRepositories func(t *testing.T) (QueueRepository, ClientRepository, queueAdmin)
+
+// queueAdmin changes queues the way the queue management service does, so
+// the suite can change a queue after a caller has read it.
+type queueAdmin interface {
+	Delete(ctx context.Context, id int32) error
+	SetState(ctx context.Context, id int32, state models.QueueState) error
+	SetTicketPrefix(ctx context.Context, id int32, prefix string) error
+}
+
+// postgresQueueAdmin changes the queues table directly.
+type postgresQueueAdmin struct {
+	db *sql.DB
+}
+
+func (a postgresQueueAdmin) Delete(ctx context.Context, id int32) error {
+	_, err := a.db.ExecContext(ctx, "UPDATE queues SET deleted_at = NOW() WHERE id = $1", id)
+	return err
+}
+
+func (a postgresQueueAdmin) SetState(ctx context.Context, id int32, state models.QueueState) error {
+	_, err := a.db.ExecContext(ctx, "UPDATE queues SET state = $2 WHERE id = $1", id, state)
+	return err
+}
+
+func (a postgresQueueAdmin) SetTicketPrefix(ctx context.Context, id int32, prefix string) error {
+	_, err := a.db.ExecContext(ctx, "UPDATE queues SET ticket_prefix = $2 WHERE id = $1", id, prefix)
+	return err
+}
+
+func TestMemoryRepositories(t *testing.T) {
+	testRepositories(t, func(t *testing.T) (QueueRepository, ClientRepository, queueAdmin) {
+		queues := NewMemoryQueueRepository()
+		return queues, NewMemoryClientRepository(queues), queues
+	})
+}
+
+func TestPostgresRepositories(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		dsn = "user=postgres password=root dbname=testDB sslmode=disable"
+	}
+	// The tests get a schema of their own so they do not disturb other
+	// tests sharing the database.
+	db, err := sql.Open("postgres", dsn+" search_path=client_repository_test")
+	require.NoError(t, err)
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Skipf("test database unavailable: %v", err)
+	}
+	createTestSchema(t, db)
+
+	testRepositories(t, func(t *testing.T) (QueueRepository, ClientRepository, queueAdmin) {
+		_, err := db.Exec("TRUNCATE TABLE clients, clients_archive, queues, ticket_sequences, counters, counter_queues RESTART IDENTITY")
+		require.NoError(t, err)
+		return NewPostgresQueueRepository(db), NewPostgresClientRepository(db), postgresQueueAdmin{db}
+	})
+}
+
+// createTestSchema recreates the test schema from the migrations. Queues and
+// their ordering belong to the queue management service, so its migrations
+// run after ours.
+func createTestSchema(t *testing.T, db *sql.DB) {
+	_, err := db.Exec("DROP SCHEMA IF EXISTS client_repository_test CASCADE; CREATE SCHEMA client_repository_test")
+	require.NoError(t, err)
+
+	clients, err := migrate.New(db, "client", migrations.FS)
+	require.NoError(t, err)
+	require.NoError(t, clients.Up(context.Background()))
+	queues, err := migrate.New(db, "queue-management", os.DirFS("../../queue-management-service/migrations"))
+	require.NoError(t, err)
+	require.NoError(t, queues.Up(context.Background()))
+}
+
+// testRepositories is the conformance suite every backend has to pass.
+func testRepositories(t *testing.T, newRepos newRepositories) {
+	ctx := context.Background()
+
+	createQueue := func(t *testing.T, queues QueueRepository, name, prefix string) *models.Queue {
+		queue := &models.Queue{Name: name, TicketPrefix: prefix, TicketPadding: 3, TicketReset: models.TicketResetDaily}
+		require.NoError(t, queues.Create(ctx, queue))
+		return queue
+	}
+	register := func(t *testing.T, clients ClientRepository, queue *models.Queue, name string, priority int32) *models.Client {
+		client := &models.Client{Name: name, Email: name + "@example.com", Priority: priority}
+		require.NoError(t, clients.Register(ctx, client, queue))
+		return client
+	}
+
+	t.Run("Queues", func(t *testing.T) {
+		queues, _, _ := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", "A")
+		assert.NotZero(t, queue.ID)
+
+		stored, err := queues.Get(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, queue, stored)
+
+		_, err = queues.Get(ctx, 999)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		queues, clients, _ := newRepos(t)
+		cash := createQueue(t, queues, "Cash desk", "A")
+		loans := createQueue(t, queues, "Loans", "")
+
+		first := register(t, clients, cash, "dias", 0)
+		second := register(t, clients, cash, "ernar", 0)
+		other := register(t, clients, loans, "aliya", 0)
+		assert.Equal(t, "A-001", first.TicketNumber)
+		assert.Equal(t, "A-002", second.TicketNumber)
+		assert.Equal(t, "001", other.TicketNumber)
+		assert.NotEqual(t, first.ID, second.ID)
+
+		stored, err := clients.Get(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, cash.ID, stored.QueueID)
+		assert.Equal(t, "ernar", stored.Name)
+		assert.Equal(t, "ernar@example.com", stored.Email)
+		assert.Equal(t, "A-002", stored.TicketNumber)
+		assert.Equal(t, models.StatusWaiting, stored.Status)
+		assert.False(t, stored.JoinedAt.IsZero())
+
+		_, err = clients.Get(ctx, 999)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("QueueDeleted", func(t *testing.T) {
+		queues, clients, admin := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", "A")
+		stored, err := queues.Get(ctx, queue.ID)
+		require.NoError(t, err)
+		require.NoError(t, admin.Delete(ctx, queue.ID))
+
+		err = clients.Register(ctx, &models.Client{Name: "late"}, stored)
+		assert.Equal(t, ErrNotFound, err)
+		_, err = queues.Get(ctx, queue.ID)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("QueueClosed", func(t *testing.T) {
+		queues, clients, admin := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", "A")
+		stored, err := queues.Get(ctx, queue.ID)
+		require.NoError(t, err)
+
+		for _, state := range []models.QueueState{models.QueueClosed, models.QueueDraining} {
+			require.NoError(t, admin.SetState(ctx, queue.ID, state))
+			err = clients.Register(ctx, &models.Client{Name: "late"}, stored)
+			assert.Equal(t, ErrQueueClosed, err, state)
+		}
+
+		// Paused queues still take clients, and the refused ones used no
+		// ticket number.
+		require.NoError(t, admin.SetState(ctx, queue.ID, models.QueuePaused))
+		client := register(t, clients, stored, "early", 0)
+		assert.Equal(t, "A-001", client.TicketNumber)
+	})
+
+	t.Run("TicketPrefixChanged", func(t *testing.T) {
+		queues, clients, admin := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", "A")
+		stored, err := queues.Get(ctx, queue.ID)
+		require.NoError(t, err)
+		require.NoError(t, admin.SetTicketPrefix(ctx, queue.ID, "C"))
+
+		client := register(t, clients, stored, "late", 0)
+		assert.Equal(t, "C-001", client.TicketNumber)
+	})
+
+	t.Run("QueueFull", func(t *testing.T) {
+		queues, clients, _ := newRepos(t)
+		full := &models.Queue{Name: "Small", TicketPadding: 3, TicketReset: models.TicketResetDaily, MaxSize: 1}
+		require.NoError(t, queues.Create(ctx, full))
+		waitlisting := &models.Queue{Name: "Waitlist", TicketPadding: 3, TicketReset: models.TicketResetDaily, MaxSize: 1,
+			OverflowPolicy: models.OverflowWaitlist}
+		require.NoError(t, queues.Create(ctx, waitlisting))
+
+		register(t, clients, full, "a", 0)
+		err := clients.Register(ctx, &models.Client{Name: "b"}, full)
+		assert.Equal(t, ErrQueueFull, err)
+
+		register(t, clients, waitlisting, "c", 0)
+		waitlisted := register(t, clients, waitlisting, "d", 0)
+		assert.Equal(t, models.StatusWaitlisted, waitlisted.Status)
+		assert.Equal(t, "002", waitlisted.TicketNumber)
+		place, err := clients.Place(ctx, waitlisting.ID, waitlisted.ID)
+		require.NoError(t, err)
+		assert.Zero(t, place, "waitlisted clients are not in line")
+	})
+
+	t.Run("Positions", func(t *testing.T) {
+		queues, clients, _ := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", "A")
+		a := register(t, clients, queue, "a", 0)
+		b := register(t, clients, queue, "b", 0)
+		vip := register(t, clients, queue, "vip", 1)
+
+		for client, want := range map[*models.Client]int32{vip: 1, a: 2, b: 3} {
+			place, err := clients.Place(ctx, queue.ID, client.ID)
+			require.NoError(t, err)
+			assert.Equal(t, want, place, client.Name)
+		}
+		place, err := clients.Place(ctx, queue.ID, 999)
+		require.NoError(t, err)
+		assert.Zero(t, place)
+
+		positions, err := clients.ActivePositions(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []Position{
+			{ClientID: vip.ID, Status: models.StatusWaiting, Place: 1},
+			{ClientID: a.ID, Status: models.StatusWaiting, Place: 2},
+			{ClientID: b.ID, Status: models.StatusWaiting, Place: 3},
+		}, positions)
+	})
+
+	t.Run("NoServiceHistory", func(t *testing.T) {
+		queues, clients, _ := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", "A")
+		register(t, clients, queue, "a", 0)
+
+		durations, err := clients.ServiceDurations(ctx, queue.ID, 20)
+		require.NoError(t, err)
+		assert.Empty(t, durations)
+
+		counters, err := clients.OpenCounters(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Zero(t, counters)
+	})
+}
@@ -1,14 +1,40 @@
 package server
 
 import (
+	"client-service/models"
 	"client-service/pb"
+	"client-service/repository"
 	"context"
+	"database/sql"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"queue-management-system/schedule"
 )
 
+// newPostgresServer returns a server backed by db.
+func newPostgresServer(db *sql.DB) *ClientServiceServer {
+	return NewClientService(repository.NewPostgresQueueRepository(db), repository.NewPostgresClientRepository(db), nil)
+}
+
+// queueQuery matches the query reading a queue, which returns queueColumns.
+const queueQuery = "SELECT id, name, ticket_prefix, ticket_padding, ticket_reset, schedule, max_size, overflow_policy, (.+), state FROM queues WHERE id = \\$1"
+
+var queueColumns = []string{"id", "name", "ticket_prefix", "ticket_padding", "ticket_reset", "schedule", "max_size", "overflow_policy", "overflow_queue_id", "state"}
+
+// lockQueueQuery matches Register locking and rereading the queue it
+// registers in, which returns lockColumns.
+const lockQueueQuery = "SELECT deleted_at, state, max_size, overflow_policy, ticket_prefix, ticket_padding, ticket_reset FROM queues WHERE id = \\$1 FOR NO KEY UPDATE"
+
+var lockColumns = []string{"deleted_at", "state", "max_size", "overflow_policy", "ticket_prefix", "ticket_padding", "ticket_reset"}
+
 func TestRegisterClient(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -16,20 +42,223 @@ func TestRegisterClient(t *testing.T) {
 	}
 	defer db.Close()
 
-	server := &ClientServiceServer{db: db}
+	server := newPostgresServer(db)
 
 	req := &pb.RegisterClientRequest{
 		QueueId: 1,
 		Name:    "Ermek Dias",
+		Email:   "ermek@example.com",
 	}
 
-	mock.ExpectExec("INSERT INTO clients").WithArgs(req.QueueId, req.Name).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(queueQuery).WithArgs(req.QueueId).
+		WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(1, "Cash desk", "A", 3, "daily", nil, 0, "reject", 0, "open"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockQueueQuery).WithArgs(req.QueueId).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(nil, "open", 0, "reject", "A", 3, "daily"))
+	mock.ExpectQuery("INSERT INTO ticket_sequences").WithArgs(req.QueueId, time.Now().Format("2006-01-02")).
+		WillReturnRows(sqlmock.NewRows([]string{"last_number"}).AddRow(42))
+	mock.ExpectQuery("INSERT INTO clients").WithArgs(req.QueueId, req.Name, req.Email, req.Priority, "A-042", models.StatusWaiting).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
 
 	resp, err := server.RegisterClient(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.True(t, resp.Success)
 	assert.Equal(t, "Client registered successfully", resp.Message)
+	assert.Equal(t, int32(1), resp.ClientId)
+	assert.Equal(t, "A-042", resp.TicketNumber)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterClientQueueNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	server := newPostgresServer(db)
+
+	mock.ExpectQuery(queueQuery).WithArgs(int32(7)).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 7, Name: "Dias Ermek"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterClientQueueDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	server := newPostgresServer(db)
+
+	mock.ExpectQuery(queueQuery).WithArgs(int32(7)).
+		WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(7, "Cash desk", "A", 3, "never", nil, 0, "reject", 0, "open"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockQueueQuery).WithArgs(int32(7)).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(time.Now(), "open", 0, "reject", "A", 3, "daily"))
+	mock.ExpectRollback()
+
+	_, err = server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 7, Name: "Dias Ermek"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "Queue not found", status.Convert(err).Message())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterClientQueueClosed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	server := newPostgresServer(db)
+
+	// The queue opens for one minute an hour from now.
+	opening := time.Now().UTC().Add(time.Hour).Truncate(time.Minute)
+	hours := schedule.Hours{Day: opening.Weekday(), Open: opening.Format("15:04"), Close: opening.Add(time.Minute).Format("15:04")}
+	if hours.Close < hours.Open {
+		hours.Close = "24:00"
+	}
+	data, err := json.Marshal(schedule.Schedule{Weekly: []schedule.Hours{hours}})
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(queueQuery).WithArgs(int32(7)).
+		WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(7, "Cash desk", "A", 3, "daily", data, 0, "reject", 0, "open"))
+
+	_, err = server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 7, Name: "Dias Ermek"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "Queue is closed until "+opening.Format(time.RFC3339), status.Convert(err).Message())
+	details := status.Convert(err).Details()
+	if assert.Len(t, details, 1) {
+		info := details[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, "QUEUE_CLOSED", info.Reason)
+		assert.Equal(t, opening.Format(time.RFC3339), info.Metadata["next_opening"])
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterClientQueueStateClosed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	server := newPostgresServer(db)
+
+	for _, state := range []string{"closed", "draining"} {
+		mock.ExpectQuery(queueQuery).WithArgs(int32(7)).
+			WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(7, "Cash desk", "A", 3, "daily", nil, 0, "reject", 0, state))
+
+		_, err = server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 7, Name: "Dias Ermek"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), state)
+		assert.Equal(t, "Queue is closed", status.Convert(err).Message())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterClientQueueClosedSinceRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	server := newPostgresServer(db)
+
+	mock.ExpectQuery(queueQuery).WithArgs(int32(7)).
+		WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(7, "Cash desk", "A", 3, "daily", nil, 0, "reject", 0, "open"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockQueueQuery).WithArgs(int32(7)).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(nil, "draining", 0, "reject", "A", 3, "daily"))
+	mock.ExpectRollback()
+
+	_, err = server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 7, Name: "Dias Ermek"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "Queue is closed", status.Convert(err).Message())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterClientQueueFull(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	server := newPostgresServer(db)
+	today := time.Now().Format("2006-01-02")
+
+	t.Run("Reject", func(t *testing.T) {
+		mock.ExpectQuery(queueQuery).WithArgs(int32(7)).
+			WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(7, "Cash desk", "A", 3, "daily", nil, 2, "reject", 0, "open"))
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQueueQuery).WithArgs(int32(7)).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(nil, "open", 2, "reject", "A", 3, "daily"))
+		mock.ExpectQuery("SELECT COUNT").WithArgs(int32(7)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectRollback()
+
+		_, err := server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 7, Name: "Dias Ermek"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, "Queue is full", status.Convert(err).Message())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Waitlist", func(t *testing.T) {
+		mock.ExpectQuery(queueQuery).WithArgs(int32(7)).
+			WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(7, "Cash desk", "A", 3, "daily", nil, 2, "waitlist", 0, "open"))
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQueueQuery).WithArgs(int32(7)).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(nil, "open", 2, "waitlist", "A", 3, "daily"))
+		mock.ExpectQuery("SELECT COUNT").WithArgs(int32(7)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery("INSERT INTO ticket_sequences").WithArgs(int32(7), today).
+			WillReturnRows(sqlmock.NewRows([]string{"last_number"}).AddRow(3))
+		mock.ExpectQuery("INSERT INTO clients").WithArgs(int32(7), "Dias Ermek", "", int32(0), "A-003", models.StatusWaitlisted).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		mock.ExpectCommit()
+
+		resp, err := server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 7, Name: "Dias Ermek"})
+		assert.NoError(t, err)
+		assert.True(t, resp.Waitlisted)
+		assert.Equal(t, int32(7), resp.QueueId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Redirect", func(t *testing.T) {
+		mock.ExpectQuery(queueQuery).WithArgs(int32(7)).
+			WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(7, "Cash desk", "A", 3, "daily", nil, 2, "redirect", 8, "open"))
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQueueQuery).WithArgs(int32(7)).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(nil, "open", 2, "redirect", "A", 3, "daily"))
+		mock.ExpectQuery("SELECT COUNT").WithArgs(int32(7)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectRollback()
+		mock.ExpectQuery(queueQuery).WithArgs(int32(8)).
+			WillReturnRows(sqlmock.NewRows(queueColumns).AddRow(8, "Overflow", "B", 3, "daily", nil, 0, "reject", 0, "open"))
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQueueQuery).WithArgs(int32(8)).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(nil, "open", 0, "reject", "B", 3, "daily"))
+		mock.ExpectQuery("INSERT INTO ticket_sequences").WithArgs(int32(8), today).
+			WillReturnRows(sqlmock.NewRows([]string{"last_number"}).AddRow(1))
+		mock.ExpectQuery("INSERT INTO clients").WithArgs(int32(8), "Dias Ermek", "", int32(0), "B-001", models.StatusWaiting).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+		mock.ExpectCommit()
+
+		resp, err := server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 7, Name: "Dias Ermek"})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(8), resp.QueueId)
+		assert.Equal(t, "B-001", resp.TicketNumber)
+		assert.False(t, resp.Waitlisted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestFormatTicketNumber(t *testing.T) {
+	assert.Equal(t, "A-042", models.FormatTicketNumber("A", 3, 42))
+	assert.Equal(t, "007", models.FormatTicketNumber("", 3, 7))
+	assert.Equal(t, "B-1234", models.FormatTicketNumber("B", 2, 1234))
+
+	day := time.Date(2024, 5, 17, 23, 59, 0, 0, time.UTC)
+	assert.Equal(t, "2024-05-17", models.TicketResetDaily.Period(day))
+	assert.Equal(t, "all", models.TicketResetNever.Period(day))
 }
 
 func TestGetClientStatus(t *testing.T) {
@@ -39,18 +268,237 @@ func TestGetClientStatus(t *testing.T) {
 	}
 	defer db.Close()
 
-	server := &ClientServiceServer{db: db}
+	server := newPostgresServer(db)
 
 	req := &pb.GetClientStatusRequest{
-		QueueId: 1,
+		ClientId: 3,
 	}
 
-	rows := sqlmock.NewRows([]string{"name"}).AddRow("Dias Ermek").AddRow("Ernar Asherbekov")
-	mock.ExpectQuery("SELECT name FROM clients WHERE queue_id = \\$1").WithArgs(req.QueueId).WillReturnRows(rows)
+	joinedAt := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "queue_id", "name", "email", "ticket_number", "status", "priority", "created_at"}).
+		AddRow(3, 1, "Dias Ermek", "dias@example.com", "A-003", "waiting", 0, joinedAt)
+	mock.ExpectQuery("SELECT id, queue_id, name, email, ticket_number, status, priority, created_at FROM clients WHERE id = \\$1").
+		WithArgs(req.ClientId).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT place FROM queue_positions\\(\\$1\\) WHERE client_id = \\$2").WithArgs(int32(1), int32(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"place"}).AddRow(3))
+	mock.ExpectQuery("SELECT EXTRACT\\(EPOCH FROM served_at - serving_started_at\\)").WithArgs(int32(1), 20).
+		WillReturnRows(sqlmock.NewRows([]string{"seconds"}).AddRow(120.0).AddRow(120.0))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM counters").WithArgs(int32(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
 	resp, err := server.GetClientStatus(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
-	assert.Equal(t, []string{"Dias Ermek", "Ernar Asherbekov"}, resp.Clients)
-	assert.Equal(t, "Client status retrieved successfully", resp.Message)
+	assert.Equal(t, "Dias Ermek", resp.Client.Name)
+	assert.Equal(t, "waiting", resp.Client.Status)
+	assert.Equal(t, int32(0), resp.Client.Priority)
+	assert.Equal(t, "A-003", resp.Client.TicketNumber)
+	assert.Equal(t, int32(3), resp.PlaceInQueue)
+	assert.Equal(t, int32(2), resp.ClientsBefore)
+	assert.Equal(t, int32(120), resp.EstimatedWaitSeconds)
+}
+
+func TestGetClientStatusNotWaiting(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	server := newPostgresServer(db)
+
+	rows := sqlmock.NewRows([]string{"id", "queue_id", "name", "email", "ticket_number", "status", "priority", "created_at"}).
+		AddRow(3, 1, "Dias Ermek", "dias@example.com", "A-003", "called", 0, time.Now())
+	mock.ExpectQuery("SELECT id, queue_id, name, email, ticket_number, status, priority, created_at FROM clients WHERE id = \\$1").
+		WithArgs(int32(3)).WillReturnRows(rows)
+
+	resp, err := server.GetClientStatus(context.Background(), &pb.GetClientStatusRequest{ClientId: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "called", resp.Client.Status)
+	assert.Equal(t, int32(0), resp.PlaceInQueue)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetClientStatusNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	server := newPostgresServer(db)
+
+	mock.ExpectQuery("SELECT id, queue_id, name, email, ticket_number, status, priority, created_at FROM clients WHERE id = \\$1").
+		WithArgs(int32(42)).WillReturnError(sql.ErrNoRows)
+
+	_, err = server.GetClientStatus(context.Background(), &pb.GetClientStatusRequest{ClientId: 42})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// expectThroughput mocks a queue served in 60 seconds per client by one counter.
+func expectThroughput(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT EXTRACT\\(EPOCH FROM served_at - serving_started_at\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"seconds"}).AddRow(60.0))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM counters").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+}
+
+type fakePositionStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *pb.PositionUpdate
+}
+
+func (f *fakePositionStream) Context() context.Context { return f.ctx }
+
+func (f *fakePositionStream) Send(update *pb.PositionUpdate) error {
+	f.updates <- update
+	return nil
+}
+
+func TestWatchPosition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clients := repository.NewPostgresClientRepository(db)
+	hub := NewPositionHub(clients)
+	go hub.Run(ctx)
+	server := NewClientService(repository.NewPostgresQueueRepository(db), clients, hub)
+
+	joinedAt := time.Now()
+	mock.ExpectQuery("SELECT id, queue_id, name, email, ticket_number, status, priority, created_at FROM clients WHERE id = \\$1").
+		WithArgs(int32(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "queue_id", "name", "email", "ticket_number", "status", "priority", "created_at"}).
+			AddRow(3, 1, "Dias Ermek", "dias@example.com", "A-003", "waiting", 0, joinedAt))
+	mock.ExpectQuery("SELECT place FROM queue_positions").
+		WillReturnRows(sqlmock.NewRows([]string{"place"}).AddRow(2))
+	expectThroughput(mock)
+
+	stream := &fakePositionStream{ctx: ctx, updates: make(chan *pb.PositionUpdate, 4)}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.WatchPosition(&pb.WatchPositionRequest{ClientId: 3}, stream)
+	}()
+
+	update := <-stream.updates
+	assert.Equal(t, int32(2), update.PlaceInQueue)
+	assert.Equal(t, int32(1), update.ClientsBefore)
+	assert.Equal(t, int32(60), update.EstimatedWaitSeconds)
+
+	// The client ahead is called: one query refreshes the whole queue.
+	expectThroughput(mock)
+	mock.ExpectQuery("SELECT c.id, c.status, COALESCE\\(p.place, 0\\) FROM clients c").WithArgs(int32(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "place"}).AddRow(2, "called", 0).AddRow(3, "waiting", 1))
+	hub.Publish(1)
+
+	update = <-stream.updates
+	assert.Equal(t, int32(1), update.PlaceInQueue)
+	assert.Equal(t, "waiting", update.Status)
+
+	// The client is served and drops out of the active queue, ending the stream.
+	expectThroughput(mock)
+	mock.ExpectQuery("SELECT c.id, c.status, COALESCE\\(p.place, 0\\) FROM clients c").WithArgs(int32(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "place"}))
+	mock.ExpectQuery("SELECT id, queue_id, name, email, ticket_number, status, priority, created_at FROM clients WHERE id = \\$1").WithArgs(int32(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "queue_id", "name", "email", "ticket_number", "status", "priority", "created_at"}).
+			AddRow(3, 1, "Dias Ermek", "dias@example.com", "A-003", "served", 0, joinedAt))
+	hub.Publish(1)
+
+	update = <-stream.updates
+	assert.Equal(t, "served", update.Status)
+	assert.NoError(t, <-done)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatchPositionClientDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clients := repository.NewPostgresClientRepository(db)
+	hub := NewPositionHub(clients)
+	go hub.Run(ctx)
+	server := NewClientService(repository.NewPostgresQueueRepository(db), clients, hub)
+
+	mock.ExpectQuery("SELECT id, queue_id, name, email, ticket_number, status, priority, created_at FROM clients WHERE id = \\$1").
+		WithArgs(int32(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "queue_id", "name", "email", "ticket_number", "status", "priority", "created_at"}).
+			AddRow(3, 1, "Dias Ermek", "dias@example.com", "A-003", "waiting", 0, time.Now()))
+	mock.ExpectQuery("SELECT place FROM queue_positions").
+		WillReturnRows(sqlmock.NewRows([]string{"place"}).AddRow(1))
+	expectThroughput(mock)
+
+	stream := &fakePositionStream{ctx: ctx, updates: make(chan *pb.PositionUpdate, 4)}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.WatchPosition(&pb.WatchPositionRequest{ClientId: 3}, stream)
+	}()
+	<-stream.updates
+
+	// The queue is deleted and takes the client along.
+	expectThroughput(mock)
+	mock.ExpectQuery("SELECT c.id, c.status, COALESCE\\(p.place, 0\\) FROM clients c").WithArgs(int32(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "place"}))
+	mock.ExpectQuery("SELECT id, queue_id, name, email, ticket_number, status, priority, created_at FROM clients WHERE id = \\$1").WithArgs(int32(3)).
+		WillReturnError(sql.ErrNoRows)
+	hub.Publish(1)
+
+	assert.Equal(t, codes.NotFound, status.Code(<-done))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatchPositionHubStopped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queues := repository.NewMemoryQueueRepository()
+	queue := &models.Queue{Name: "Default", TicketPadding: models.DefaultTicketPadding, TicketReset: models.TicketResetDaily}
+	assert.NoError(t, queues.Create(ctx, queue))
+	clients := repository.NewMemoryClientRepository(queues)
+	hub := NewPositionHub(clients)
+	hubCtx, stop := context.WithCancel(ctx)
+	go hub.Run(hubCtx)
+	server := NewClientService(queues, clients, hub)
+
+	registered, err := server.RegisterClient(ctx, &pb.RegisterClientRequest{QueueId: queue.ID, Name: "Dias Ermek"})
+	assert.NoError(t, err)
+
+	stream := &fakePositionStream{ctx: ctx, updates: make(chan *pb.PositionUpdate, 4)}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.WatchPosition(&pb.WatchPositionRequest{ClientId: registered.ClientId}, stream)
+	}()
+	<-stream.updates
+
+	stop()
+	assert.Equal(t, codes.Unavailable, status.Code(<-done))
+}
+
+func TestPublishCoalescesDroppedSignals(t *testing.T) {
+	hub := NewPositionHub(repository.NewMemoryClientRepository(repository.NewMemoryQueueRepository()))
+
+	// Nothing runs the hub, so the signals pile up and overflow.
+	for i := 0; i < 1000; i++ {
+		hub.Publish(int32(i))
+	}
+	assert.Len(t, hub.changed, cap(hub.changed))
+	assert.Len(t, hub.overflowed, 1, "dropped signals share one full refresh")
+}
+
+func TestRegisterClientNegativePriority(t *testing.T) {
+	server := newPostgresServer(nil)
+
+	_, err := server.RegisterClient(context.Background(), &pb.RegisterClientRequest{QueueId: 1, Name: "Dias Ermek", Priority: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
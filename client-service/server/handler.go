@@ -1,63 +1,239 @@
 package server
 
 import (
+	"client-service/models"
 	"client-service/pb"
+	"client-service/repository"
 	"context"
-	"database/sql"
-	_ "github.com/lib/pq"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"log"
-	"os"
+	"queue-management-system/eta"
 )
 
 type ClientServiceServer struct {
 	pb.UnimplementedClientServiceServer
-	db *sql.DB
+	queues  repository.QueueRepository
+	clients repository.ClientRepository
+	hub     *PositionHub
 }
 
-func NewClientService() *ClientServiceServer {
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		dsn = "user=postgres password=postgres dbname=queue_management sslmode=disable"
-	}
-	db, err := sql.Open("postgres", dsn)
-	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
-	}
-	return &ClientServiceServer{db: db}
+// NewClientService returns a server registering clients in queues and
+// publishing their changes to hub.
+func NewClientService(queues repository.QueueRepository, clients repository.ClientRepository, hub *PositionHub) *ClientServiceServer {
+	return &ClientServiceServer{queues: queues, clients: clients, hub: hub}
 }
 
 func (s *ClientServiceServer) RegisterClient(ctx context.Context, req *pb.RegisterClientRequest) (*pb.RegisterClientResponse, error) {
 	if req.QueueId == 0 || req.Name == "" {
 		return nil, status.Error(codes.InvalidArgument, "Queue ID and client name are required")
 	}
+	if req.Priority < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Priority must not be negative")
+	}
 
-	_, err := s.db.Exec("INSERT INTO clients (queue_id, name) VALUES ($1, $2)", req.QueueId, req.Name)
+	queue, err := s.queues.Get(ctx, req.QueueId)
 	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Queue not found")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.RegisterClientResponse{Success: true, Message: "Client registered successfully"}, nil
+	if !queue.State.AcceptsClients() {
+		return nil, status.Error(codes.FailedPrecondition, "Queue is closed")
+	}
+	if now := time.Now(); !queue.IsOpen(now) {
+		return nil, queueClosedError(queue, now)
+	}
+
+	client := &models.Client{Name: req.Name, Email: req.Email, Priority: req.Priority}
+	if err := s.register(ctx, client, queue); err != nil {
+		return nil, err
+	}
+	s.hub.Publish(client.QueueID)
+	return &pb.RegisterClientResponse{
+		Success:      true,
+		Message:      "Client registered successfully",
+		ClientId:     client.ID,
+		TicketNumber: client.TicketNumber,
+		QueueId:      client.QueueID,
+		Waitlisted:   client.Status == models.StatusWaitlisted,
+	}, nil
+}
+
+// register registers client in queue. A full queue that redirects passes
+// the client on to its overflow queue, which may redirect in turn; a chain
+// leading back to a queue already tried, or into a missing or closed queue,
+// ends with the client turned away.
+func (s *ClientServiceServer) register(ctx context.Context, client *models.Client, queue *models.Queue) error {
+	tried := make(map[int32]bool)
+	for {
+		err := s.clients.Register(ctx, client, queue)
+		switch {
+		case err == nil:
+			return nil
+		case err == repository.ErrNotFound:
+			return status.Error(codes.NotFound, "Queue not found")
+		case err == repository.ErrQueueClosed && len(tried) == 0:
+			// The queue closed since it was read.
+			return status.Error(codes.FailedPrecondition, "Queue is closed")
+		case err == repository.ErrQueueClosed:
+			return status.Error(codes.ResourceExhausted, "Queue is full")
+		case err != repository.ErrQueueFull:
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		tried[queue.ID] = true
+		if queue.OverflowPolicy != models.OverflowRedirect || queue.OverflowQueueID == 0 || tried[queue.OverflowQueueID] {
+			return status.Error(codes.ResourceExhausted, "Queue is full")
+		}
+		overflow, err := s.queues.Get(ctx, queue.OverflowQueueID)
+		if err == repository.ErrNotFound || (err == nil && (!overflow.State.AcceptsClients() || !overflow.IsOpen(time.Now()))) {
+			return status.Error(codes.ResourceExhausted, "Queue is full")
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		queue = overflow
+	}
+}
+
+// queueClosedError tells a client the queue is closed at now and, unless it
+// never opens again, when it opens. The time is also attached as the
+// next_opening metadata of a QUEUE_CLOSED ErrorInfo, formatted as RFC 3339 in
+// the queue's time zone.
+func queueClosedError(queue *models.Queue, now time.Time) error {
+	next, ok := queue.Schedule.NextOpening(now)
+	if !ok {
+		return status.Error(codes.FailedPrecondition, "Queue is closed")
+	}
+	opening := next.Format(time.RFC3339)
+	st, err := status.New(codes.FailedPrecondition, "Queue is closed until "+opening).WithDetails(&errdetails.ErrorInfo{
+		Reason:   "QUEUE_CLOSED",
+		Domain:   "client-service",
+		Metadata: map[string]string{"next_opening": opening},
+	})
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, "Queue is closed until "+opening)
+	}
+	return st.Err()
 }
 
+// GetClientStatus returns a client together with its 1-based place among the
+// waiting clients of its queue, as ordered by the queue's ordering policy.
+// Clients that are no longer waiting report a place of zero.
 func (s *ClientServiceServer) GetClientStatus(ctx context.Context, req *pb.GetClientStatusRequest) (*pb.GetClientStatusResponse, error) {
-	if req.QueueId == 0 {
-		return nil, status.Error(codes.InvalidArgument, "Queue ID is required")
+	if req.ClientId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Client ID is required")
 	}
 
-	rows, err := s.db.Query("SELECT name FROM clients WHERE queue_id = $1", req.QueueId)
+	client, err := s.loadClient(ctx, req.ClientId)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
-	defer rows.Close()
+	return s.clientStatus(ctx, client)
+}
 
-	var clients []string
-	for rows.Next() {
-		var clientName string
-		if err := rows.Scan(&clientName); err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+// WatchPosition streams the client's place in its queue, sending an update
+// every time it changes, until the client leaves the queue or the caller
+// goes away. The stream fails with NotFound when the client is deleted.
+func (s *ClientServiceServer) WatchPosition(req *pb.WatchPositionRequest, stream pb.ClientService_WatchPositionServer) error {
+	if req.ClientId == 0 {
+		return status.Error(codes.InvalidArgument, "Client ID is required")
+	}
+	ctx := stream.Context()
+
+	client, err := s.loadClient(ctx, req.ClientId)
+	if err != nil {
+		return err
+	}
+
+	// Subscribe before reading the initial position so no change is missed.
+	watcher, unsubscribe := s.hub.subscribe(client.QueueID, client.ID)
+	defer unsubscribe()
+
+	current, err := s.clientStatus(ctx, client)
+	if err != nil {
+		return err
+	}
+	last := &pb.PositionUpdate{
+		ClientId:             client.ID,
+		Status:               current.Client.Status,
+		PlaceInQueue:         current.PlaceInQueue,
+		ClientsBefore:        current.ClientsBefore,
+		EstimatedWaitSeconds: current.EstimatedWaitSeconds,
+	}
+	if err := stream.Send(last); err != nil {
+		return err
+	}
+
+	for !isFinalStatus(last.Status) {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.hub.Stopped():
+			return status.Error(codes.Unavailable, "Server is shutting down")
+		case update := <-watcher.updates:
+			if update == nil {
+				return status.Error(codes.NotFound, "Client not found")
+			}
+			if update.Status == last.Status && update.PlaceInQueue == last.PlaceInQueue &&
+				update.EstimatedWaitSeconds == last.EstimatedWaitSeconds {
+				continue
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			last = update
 		}
-		clients = append(clients, clientName)
 	}
-	return &pb.GetClientStatusResponse{Clients: clients, Message: "Client status retrieved successfully"}, nil
+	return nil
+}
+
+func (s *ClientServiceServer) loadClient(ctx context.Context, clientID int32) (*models.Client, error) {
+	client, err := s.clients.Get(ctx, clientID)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Client not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return client, nil
+}
+
+func (s *ClientServiceServer) clientStatus(ctx context.Context, client *models.Client) (*pb.GetClientStatusResponse, error) {
+	resp := &pb.GetClientStatusResponse{
+		Client: &pb.Client{
+			Id:           client.ID,
+			Name:         client.Name,
+			Email:        client.Email,
+			Status:       string(client.Status),
+			Priority:     client.Priority,
+			TicketNumber: client.TicketNumber,
+		},
+	}
+	if client.Status != models.StatusWaiting {
+		return resp, nil
+	}
+
+	place, err := s.clients.Place(ctx, client.QueueID, client.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if place == 0 {
+		// Called between reading the client and its position.
+		return resp, nil
+	}
+	before := place - 1
+
+	throughput, err := eta.Load(ctx, s.clients, client.QueueID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp.ClientsBefore = before
+	resp.PlaceInQueue = place
+	resp.EstimatedWaitSeconds = throughput.Wait(before)
+	return resp, nil
 }
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"client-service/models"
+	"client-service/pb"
+	"client-service/repository"
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"queue-management-system/eta"
+)
+
+// clientsChangedChannel is the NOTIFY channel raised by the clients table
+// trigger; the payload is the ID of the queue whose clients changed.
+const clientsChangedChannel = "clients_changed"
+
+// pollInterval is how often watched queues are refreshed while the LISTEN
+// connection is down and only in-process changes are being published.
+const pollInterval = 5 * time.Second
+
+// PositionHub fans queue changes out to WatchPosition streams. A change to a
+// queue triggers a single query for the whole queue, whose result is shared
+// by every watcher of that queue.
+type PositionHub struct {
+	clients  repository.ClientRepository
+	mu       sync.Mutex
+	watchers map[int32]map[*positionWatcher]struct{}
+	changed  chan int32
+	// overflowed asks Run for a refresh of every watched queue. It holds
+	// one request, so any number of dropped signals cost one refresh.
+	overflowed chan struct{}
+	stopped    chan struct{}
+	listening  bool
+}
+
+type positionWatcher struct {
+	clientID int32
+	// updates carries the latest position, or nil once the client no longer
+	// exists.
+	updates chan *pb.PositionUpdate
+}
+
+func NewPositionHub(clients repository.ClientRepository) *PositionHub {
+	return &PositionHub{
+		clients:    clients,
+		watchers:   make(map[int32]map[*positionWatcher]struct{}),
+		changed:    make(chan int32, 64),
+		overflowed: make(chan struct{}, 1),
+		stopped:    make(chan struct{}),
+	}
+}
+
+// Publish signals that the clients of a queue changed. It never blocks; when
+// the hub is busy the signal is dropped in favour of a full refresh.
+func (h *PositionHub) Publish(queueID int32) {
+	if h == nil {
+		return
+	}
+	select {
+	case h.changed <- queueID:
+	default:
+		h.requestRefreshAll()
+	}
+}
+
+// requestRefreshAll has Run refresh every watched queue, unless a refresh
+// is already requested.
+func (h *PositionHub) requestRefreshAll() {
+	select {
+	case h.overflowed <- struct{}{}:
+	default:
+	}
+}
+
+// Stopped is closed once Run returns, after which watchers get no updates.
+func (h *PositionHub) Stopped() <-chan struct{} {
+	return h.stopped
+}
+
+func (h *PositionHub) subscribe(queueID, clientID int32) (*positionWatcher, func()) {
+	w := &positionWatcher{clientID: clientID, updates: make(chan *pb.PositionUpdate, 1)}
+
+	h.mu.Lock()
+	if h.watchers[queueID] == nil {
+		h.watchers[queueID] = make(map[*positionWatcher]struct{})
+	}
+	h.watchers[queueID][w] = struct{}{}
+	h.mu.Unlock()
+
+	return w, func() {
+		h.mu.Lock()
+		delete(h.watchers[queueID], w)
+		if len(h.watchers[queueID]) == 0 {
+			delete(h.watchers, queueID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// Run processes published changes until ctx is cancelled. Signals that pile up
+// while a refresh is in progress are coalesced into one refresh per queue.
+func (h *PositionHub) Run(ctx context.Context) {
+	defer close(h.stopped)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case queueID := <-h.changed:
+			pending := map[int32]struct{}{queueID: {}}
+		drain:
+			for {
+				select {
+				case id := <-h.changed:
+					pending[id] = struct{}{}
+				default:
+					break drain
+				}
+			}
+			for id := range pending {
+				h.refresh(ctx, id)
+			}
+		case <-h.overflowed:
+			h.refreshAll(ctx)
+		case <-ticker.C:
+			h.mu.Lock()
+			listening := h.listening
+			h.mu.Unlock()
+			if !listening {
+				h.refreshAll(ctx)
+			}
+		}
+	}
+}
+
+// Listen subscribes to clients_changed notifications from PostgreSQL and
+// publishes them to the hub until ctx is cancelled.
+func (h *PositionHub) Listen(ctx context.Context, dsn string) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		h.mu.Lock()
+		h.listening = ev == pq.ListenerEventConnected || ev == pq.ListenerEventReconnected
+		h.mu.Unlock()
+		if err != nil {
+			log.Printf("clients listener: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(clientsChangedChannel); err != nil {
+		log.Printf("failed to listen on %s, falling back to in-process updates: %v", clientsChangedChannel, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-listener.Notify:
+			if n == nil {
+				// Notifications may have been missed while reconnecting.
+				h.requestRefreshAll()
+				continue
+			}
+			queueID, err := strconv.ParseInt(n.Extra, 10, 32)
+			if err != nil {
+				log.Printf("clients listener: invalid payload %q", n.Extra)
+				continue
+			}
+			h.Publish(int32(queueID))
+		}
+	}
+}
+
+func (h *PositionHub) refreshAll(ctx context.Context) {
+	h.mu.Lock()
+	queueIDs := make([]int32, 0, len(h.watchers))
+	for id := range h.watchers {
+		queueIDs = append(queueIDs, id)
+	}
+	h.mu.Unlock()
+
+	for _, id := range queueIDs {
+		h.refresh(ctx, id)
+	}
+}
+
+func (h *PositionHub) refresh(ctx context.Context, queueID int32) {
+	h.mu.Lock()
+	watchers := make([]*positionWatcher, 0, len(h.watchers[queueID]))
+	for w := range h.watchers[queueID] {
+		watchers = append(watchers, w)
+	}
+	h.mu.Unlock()
+	if len(watchers) == 0 {
+		return
+	}
+
+	positions, err := h.loadQueue(ctx, queueID)
+	if err != nil {
+		log.Printf("failed to refresh positions for queue %d: %v", queueID, err)
+		return
+	}
+
+	for _, w := range watchers {
+		update, ok := positions[w.clientID]
+		if !ok {
+			// The client has left the active part of the queue. A client
+			// that no longer exists gets a nil update, ending its stream.
+			update, err = h.loadClient(ctx, w.clientID)
+			if err != nil && err != repository.ErrNotFound {
+				log.Printf("failed to load client %d: %v", w.clientID, err)
+				continue
+			}
+		}
+		w.deliver(update)
+	}
+}
+
+// loadQueue computes the current update for every active client of a queue.
+func (h *PositionHub) loadQueue(ctx context.Context, queueID int32) (map[int32]*pb.PositionUpdate, error) {
+	throughput, err := eta.Load(ctx, h.clients, queueID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := h.clients.ActivePositions(ctx, queueID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[int32]*pb.PositionUpdate, len(active))
+	for _, p := range active {
+		update := &pb.PositionUpdate{ClientId: p.ClientID, Status: string(p.Status), PlaceInQueue: p.Place}
+		if update.PlaceInQueue > 0 {
+			update.ClientsBefore = update.PlaceInQueue - 1
+			update.EstimatedWaitSeconds = throughput.Wait(update.ClientsBefore)
+		}
+		positions[update.ClientId] = update
+	}
+	return positions, nil
+}
+
+func (h *PositionHub) loadClient(ctx context.Context, clientID int32) (*pb.PositionUpdate, error) {
+	client, err := h.clients.Get(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PositionUpdate{ClientId: clientID, Status: string(client.Status)}, nil
+}
+
+// deliver hands an update to the watcher, replacing any update it has not
+// consumed yet so slow streams only ever see the latest position.
+func (w *positionWatcher) deliver(update *pb.PositionUpdate) {
+	for {
+		select {
+		case w.updates <- update:
+			return
+		default:
+		}
+		select {
+		case <-w.updates:
+		default:
+		}
+	}
+}
+
+func isFinalStatus(s string) bool {
+	switch models.ClientStatus(s) {
+	case models.StatusServed, models.StatusNoShow, models.StatusCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+// Package eta estimates how long clients wait for their turn. The client
+// service tells a client its estimate and the queue management service shows
+// one for a whole queue, so both compute it here.
+package eta
+
+import (
+	"context"
+	"math"
+)
+
+const (
+	// SampleSize is how many recent services feed the average duration.
+	SampleSize = 20
+	// smoothing is the EWMA weight of the most recent service.
+	smoothing = 0.3
+	// DefaultServiceSeconds is assumed until a queue has served anyone.
+	DefaultServiceSeconds = 300
+)
+
+// History is what the estimate is based on. The client repositories of both
+// services provide it.
+type History interface {
+	// ServiceDurations returns how long the last limit services of a queue
+	// took in seconds, newest first.
+	ServiceDurations(ctx context.Context, queueID int32, limit int) ([]float64, error)
+	// OpenCounters counts the open counters assigned to a queue.
+	OpenCounters(ctx context.Context, queueID int32) (int32, error)
+}
+
+// Throughput describes how fast a queue is currently being served.
+type Throughput struct {
+	AvgServiceSeconds float64
+	OpenCounters      int32
+}
+
+// Load computes an exponentially weighted average of the queue's recent
+// service durations and counts the open counters serving it.
+func Load(ctx context.Context, history History, queueID int32) (Throughput, error) {
+	newestFirst, err := history.ServiceDurations(ctx, queueID, SampleSize)
+	if err != nil {
+		return Throughput{}, err
+	}
+	counters, err := history.OpenCounters(ctx, queueID)
+	if err != nil {
+		return Throughput{}, err
+	}
+	return Throughput{AvgServiceSeconds: ewma(newestFirst), OpenCounters: counters}, nil
+}
+
+// Wait returns the expected wait in seconds for a client with the given
+// number of clients ahead of it. Without an open counter it assumes one will
+// open.
+func (t Throughput) Wait(ahead int32) int32 {
+	if ahead <= 0 {
+		return 0
+	}
+	counters := t.OpenCounters
+	if counters < 1 {
+		counters = 1
+	}
+	return int32(math.Ceil(t.AvgServiceSeconds * float64(ahead) / float64(counters)))
+}
+
+// ewma averages durations given newest first, weighting recent ones more.
+func ewma(newestFirst []float64) float64 {
+	if len(newestFirst) == 0 {
+		return DefaultServiceSeconds
+	}
+	avg := newestFirst[len(newestFirst)-1]
+	for i := len(newestFirst) - 2; i >= 0; i-- {
+		avg = smoothing*newestFirst[i] + (1-smoothing)*avg
+	}
+	return avg
+}
@@ -0,0 +1,51 @@
+package eta
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHistory struct {
+	durations []float64
+	counters  int32
+	limit     int
+}
+
+func (f *fakeHistory) ServiceDurations(ctx context.Context, queueID int32, limit int) ([]float64, error) {
+	f.limit = limit
+	return f.durations, nil
+}
+
+func (f *fakeHistory) OpenCounters(ctx context.Context, queueID int32) (int32, error) {
+	return f.counters, nil
+}
+
+func TestEWMA(t *testing.T) {
+	// No history yet: the default service time is assumed.
+	assert.Equal(t, float64(DefaultServiceSeconds), ewma(nil))
+
+	// Recent services weigh more than older ones.
+	assert.InDelta(t, 0.3*60+0.7*120, ewma([]float64{60, 120}), 0.001)
+}
+
+func TestWait(t *testing.T) {
+	throughput := Throughput{AvgServiceSeconds: 90, OpenCounters: 2}
+	assert.Equal(t, int32(0), throughput.Wait(0))
+	assert.Equal(t, int32(135), throughput.Wait(3))
+
+	// A queue without open counters is treated as having one.
+	throughput.OpenCounters = 0
+	assert.Equal(t, int32(270), throughput.Wait(3))
+}
+
+func TestLoad(t *testing.T) {
+	history := &fakeHistory{durations: []float64{60, 120}, counters: 3}
+	throughput, err := Load(context.Background(), history, 1)
+	require.NoError(t, err)
+	assert.Equal(t, SampleSize, history.limit)
+	assert.InDelta(t, 0.3*60+0.7*120, throughput.AvgServiceSeconds, 0.001)
+	assert.Equal(t, int32(3), throughput.OpenCounters)
+}
@@ -0,0 +1,413 @@
+// Package migrate applies the SQL migrations embedded in the service
+// binaries. Applied versions are recorded per service in the
+// schema_migrations table, and every run holds a PostgreSQL advisory lock so
+// replicas starting at the same time migrate one after the other.
+//
+// Migrations are pairs of files named <version>_<name>.up.sql and
+// <version>_<name>.down.sql, e.g. 000003_queue_tickets.up.sql. Each one runs
+// in its own transaction together with its schema_migrations row.
+//
+// The services share one database, and the migrations of one may build on
+// those of another. A Migrator told so with DependsOn refuses to apply its
+// migrations before the other service's are, and a service reading tables
+// another service migrates checks them with Require before serving.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// lockKey is the advisory lock taken while migrating. All services share it
+// since their migrations touch the same database.
+const lockKey = 72_616_001
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a numbered schema change and its reversal.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status tells whether a migration has been applied.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Load reads the migrations in the root of fsys, ordered by version. Files
+// that are not named like migrations are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%s: invalid version", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies the migrations of one service.
+type Migrator struct {
+	db         *sql.DB
+	service    string
+	migrations []Migration
+	dependency *dependency
+}
+
+// dependency is a version of the migrations of another service.
+type dependency struct {
+	service string
+	version int64
+}
+
+// New returns a Migrator for the migrations of service found in fsys.
+func New(db *sql.DB, service string, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, service: service, migrations: migrations}, nil
+}
+
+// DependsOn makes the migrator apply migrations only once the migrations of
+// service are applied up to version.
+func (m *Migrator) DependsOn(service string, version int64) {
+	m.dependency = &dependency{service: service, version: version}
+}
+
+// Latest returns the version of the newest migration, or zero when there
+// are none.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]time.Time) error {
+		current := currentVersion(applied)
+		if current == 0 {
+			return nil
+		}
+		var previous int64
+		for version := range applied {
+			if version < current && version > previous {
+				previous = version
+			}
+		}
+		return m.migrate(ctx, conn, applied, previous)
+	})
+}
+
+// To applies or reverts migrations until version is the newest one applied.
+// Version zero reverts all of them.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]time.Time) error {
+		return m.migrate(ctx, conn, applied, version)
+	})
+}
+
+// Status lists every migration and when it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]time.Time) error {
+		for _, migration := range m.migrations {
+			s := Status{Migration: migration}
+			if at, ok := applied[migration.Version]; ok {
+				s.AppliedAt = &at
+			}
+			statuses = append(statuses, s)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// migrate reverts the applied migrations newer than target, newest first,
+// then applies the pending ones up to target, oldest first.
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, applied map[int64]time.Time, target int64) error {
+	var revert []int64
+	for version := range applied {
+		if version > target {
+			revert = append(revert, version)
+		}
+	}
+	sort.Slice(revert, func(i, j int) bool { return revert[i] > revert[j] })
+	if d := m.dependency; d != nil && m.pending(applied, target) {
+		if err := checkApplied(ctx, conn, d.service, d.version); err != nil {
+			return fmt.Errorf("migrating %s: %w", m.service, err)
+		}
+	}
+	for _, version := range revert {
+		migration := m.find(version)
+		if migration == nil {
+			return fmt.Errorf("applied migration %d is unknown to this binary", version)
+		}
+		err := m.run(ctx, conn, migration.Down,
+			"DELETE FROM schema_migrations WHERE service = $1 AND version = $2", m.service, version)
+		if err != nil {
+			return fmt.Errorf("reverting %d_%s: %w", version, migration.Name, err)
+		}
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok || migration.Version > target {
+			continue
+		}
+		err := m.run(ctx, conn, migration.Up,
+			"INSERT INTO schema_migrations (service, version, name) VALUES ($1, $2, $3)", m.service, migration.Version, migration.Name)
+		if err != nil {
+			return fmt.Errorf("applying %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// pending reports whether migrating to target applies any migration.
+func (m *Migrator) pending(applied map[int64]time.Time, target int64) bool {
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= target {
+			return true
+		}
+	}
+	return false
+}
+
+// run executes a migration script and records it in one transaction.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, script, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// withLock calls fn holding the migration lock, with the versions applied
+// so far. The lock belongs to a session, so fn must use conn.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, applied map[int64]time.Time) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return err
+	}
+	defer func() {
+		// The lock is released when the session ends, so a failed unlock
+		// only matters if the connection is reused.
+		if _, unlockErr := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			service VARCHAR(50) NOT NULL,
+			version BIGINT NOT NULL,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (service, version)
+		)`)
+	if err != nil {
+		return err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations WHERE service = $1", m.service)
+	if err != nil {
+		return err
+	}
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			rows.Close()
+			return err
+		}
+		applied[version] = at
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return fn(conn, applied)
+}
+
+// Require checks that the migrations of service are applied up to version.
+// Services call it before serving, so one started against a database that
+// lacks the tables another service migrates fails right away and says so.
+func Require(ctx context.Context, db *sql.DB, service string, version int64) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return checkApplied(ctx, conn, service, version)
+}
+
+func checkApplied(ctx context.Context, conn *sql.Conn, service string, version int64) error {
+	// Nothing has been migrated yet when schema_migrations is missing.
+	var migrated bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&migrated); err != nil {
+		return err
+	}
+	var current int64
+	if migrated {
+		err := conn.QueryRowContext(ctx,
+			"SELECT COALESCE(MAX(version), 0) FROM schema_migrations WHERE service = $1", service).Scan(&current)
+		if err != nil {
+			return err
+		}
+	}
+	if current < version {
+		return &DependencyError{Service: service, Version: version, Applied: current}
+	}
+	return nil
+}
+
+// DependencyError is returned when the migrations of another service are
+// not applied up to the version needed.
+type DependencyError struct {
+	Service string
+	Version int64
+	Applied int64
+}
+
+func (e *DependencyError) Error() string {
+	return fmt.Sprintf("the %s migrations are applied up to version %d, but version %d is needed: migrate the %s service first",
+		e.Service, e.Applied, e.Version, e.Service)
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+func currentVersion(applied map[int64]time.Time) int64 {
+	var current int64
+	for version := range applied {
+		if version > current {
+			current = version
+		}
+	}
+	return current
+}
+
+// ErrUsage is returned by Run for arguments it does not understand.
+var ErrUsage = errors.New("usage: migrate up|down|status|to <version>")
+
+// Run carries out the migrate subcommand of a service binary, given the
+// arguments following "migrate", and reports to w.
+func Run(ctx context.Context, m *Migrator, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		if err := m.Up(ctx); err != nil {
+			return err
+		}
+	case args[0] == "down" && len(args) == 1:
+		if err := m.Down(ctx); err != nil {
+			return err
+		}
+	case args[0] == "to" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return ErrUsage
+		}
+		if err := m.To(ctx, version); err != nil {
+			return err
+		}
+	case args[0] == "status" && len(args) == 1:
+	default:
+		return ErrUsage
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = "applied " + s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%06d %-30s %s\n", s.Version, s.Name, applied)
+	}
+	return nil
+}
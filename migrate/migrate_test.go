@@ -0,0 +1,246 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFS = fstest.MapFS{
+	"000001_create_queues.up.sql":     {Data: []byte("CREATE TABLE queues (id SERIAL PRIMARY KEY);")},
+	"000001_create_queues.down.sql":   {Data: []byte("DROP TABLE IF EXISTS queues;")},
+	"000002_queue_tickets.up.sql":     {Data: []byte("ALTER TABLE queues ADD COLUMN ticket_prefix TEXT;")},
+	"000002_queue_tickets.down.sql":   {Data: []byte("ALTER TABLE queues DROP COLUMN ticket_prefix;")},
+	"000010_queue_positions.up.sql":   {Data: []byte("CREATE FUNCTION queue_positions();")},
+	"000010_queue_positions.down.sql": {Data: []byte("DROP FUNCTION queue_positions();")},
+	"embed.go":                        {Data: []byte("package migrations")},
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.Equal(t, Migration{
+		Version: 1,
+		Name:    "create_queues",
+		Up:      "CREATE TABLE queues (id SERIAL PRIMARY KEY);",
+		Down:    "DROP TABLE IF EXISTS queues;",
+	}, migrations[0])
+	assert.Equal(t, "queue_tickets", migrations[1].Name)
+	assert.Equal(t, int64(10), migrations[2].Version)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing down": {
+			"000001_create_queues.up.sql": {Data: []byte("CREATE TABLE queues ();")},
+		},
+		"conflicting names": {
+			"000001_create_queues.up.sql":    {Data: []byte("CREATE TABLE queues ();")},
+			"000001_create_clients.down.sql": {Data: []byte("DROP TABLE clients;")},
+		},
+		"version zero": {
+			"000000_create_queues.up.sql":   {Data: []byte("CREATE TABLE queues ();")},
+			"000000_create_queues.down.sql": {Data: []byte("DROP TABLE queues;")},
+		},
+	}
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// expectLock expects a migrator to lock, prepare schema_migrations and read
+// the versions applied to the test service.
+func expectLock(mock sqlmock.Sqlmock, applied ...int64) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range applied {
+		rows.AddRow(version, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations WHERE service = $1")).
+		WithArgs("test").WillReturnRows(rows)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectApply(mock sqlmock.Sqlmock, script string, version int64, name string) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(script)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO schema_migrations").WithArgs("test", version, name).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func expectRevert(mock sqlmock.Sqlmock, script string, version int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(script)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations").WithArgs("test", version).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	m, err := New(db, "test", testFS)
+	require.NoError(t, err)
+	return m, mock
+}
+
+func TestUp(t *testing.T) {
+	m, mock := newTestMigrator(t)
+	expectLock(mock, 1)
+	expectApply(mock, "ALTER TABLE queues ADD COLUMN ticket_prefix TEXT;", 2, "queue_tickets")
+	expectApply(mock, "CREATE FUNCTION queue_positions();", 10, "queue_positions")
+	expectUnlock(mock)
+
+	require.NoError(t, m.Up(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpFailureKeepsEarlierMigrations(t *testing.T) {
+	m, mock := newTestMigrator(t)
+	expectLock(mock)
+	expectApply(mock, "CREATE TABLE queues (id SERIAL PRIMARY KEY);", 1, "create_queues")
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE queues ADD COLUMN ticket_prefix TEXT;")).WillReturnError(context.DeadlineExceeded)
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	err := m.Up(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "applying 2_queue_tickets")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDown(t *testing.T) {
+	m, mock := newTestMigrator(t)
+	expectLock(mock, 1, 2)
+	expectRevert(mock, "ALTER TABLE queues DROP COLUMN ticket_prefix;", 2)
+	expectUnlock(mock)
+
+	require.NoError(t, m.Down(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTo(t *testing.T) {
+	m, mock := newTestMigrator(t)
+	expectLock(mock, 1, 2, 10)
+	expectRevert(mock, "DROP FUNCTION queue_positions();", 10)
+	expectRevert(mock, "ALTER TABLE queues DROP COLUMN ticket_prefix;", 2)
+	expectUnlock(mock)
+
+	require.NoError(t, m.To(context.Background(), 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Error(t, m.To(context.Background(), 3), "unknown version")
+}
+
+// expectApplied expects the version of the other service's migrations to be
+// read.
+func expectApplied(mock sqlmock.Sqlmock, service string, version int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('schema_migrations') IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"migrated"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM schema_migrations WHERE service = $1")).
+		WithArgs(service).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+}
+
+func TestDependsOn(t *testing.T) {
+	t.Run("Missing", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		m.DependsOn("client", 9)
+		expectLock(mock)
+		expectApplied(mock, "client", 8)
+		expectUnlock(mock)
+
+		err := m.Up(context.Background())
+		var dependency *DependencyError
+		require.ErrorAs(t, err, &dependency)
+		assert.Equal(t, DependencyError{Service: "client", Version: 9, Applied: 8}, *dependency)
+		assert.Contains(t, err.Error(), "migrate the client service first")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Applied", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		m.DependsOn("client", 9)
+		expectLock(mock, 1, 2)
+		expectApplied(mock, "client", 9)
+		expectApply(mock, "CREATE FUNCTION queue_positions();", 10, "queue_positions")
+		expectUnlock(mock)
+
+		require.NoError(t, m.Up(context.Background()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NothingPending", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		m.DependsOn("client", 9)
+		expectLock(mock, 1, 2, 10)
+		expectRevert(mock, "DROP FUNCTION queue_positions();", 10)
+		expectUnlock(mock)
+
+		require.NoError(t, m.Down(context.Background()), "reverting does not need the other service")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRequire(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('schema_migrations') IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"migrated"}).AddRow(false))
+	err = Require(context.Background(), db, "queue-management", 11)
+	assert.EqualError(t, err, "the queue-management migrations are applied up to version 0, but version 11 is needed: migrate the queue-management service first")
+
+	expectApplied(mock, "queue-management", 11)
+	assert.NoError(t, Require(context.Background(), db, "queue-management", 11))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnknownAppliedVersion(t *testing.T) {
+	m, mock := newTestMigrator(t)
+	expectLock(mock, 1, 2, 10, 11)
+	expectUnlock(mock)
+
+	assert.Error(t, m.Down(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunStatus(t *testing.T) {
+	m, mock := newTestMigrator(t)
+	expectLock(mock, 1)
+	expectUnlock(mock)
+
+	var out bytes.Buffer
+	require.NoError(t, Run(context.Background(), m, []string{"status"}, &out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "create_queues")
+	assert.Contains(t, lines[0], "applied 2024-05-01T12:00:00Z")
+	assert.True(t, strings.HasPrefix(lines[2], "000010 queue_positions"), lines[2])
+	assert.True(t, strings.HasSuffix(lines[2], "pending"), lines[2])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunUsage(t *testing.T) {
+	m, _ := newTestMigrator(t)
+	for _, args := range [][]string{nil, {"sideways"}, {"to"}, {"to", "x"}, {"up", "2"}} {
+		assert.Equal(t, ErrUsage, Run(context.Background(), m, args, &bytes.Buffer{}), args)
+	}
+}
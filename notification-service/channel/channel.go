@@ -0,0 +1,74 @@
+// Package channel delivers notifications over the supported transports.
+// Every transport implements Channel and is looked up by name in a Registry.
+package channel
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrRecipientRequired is returned by channels for messages without a
+// recipient.
+var ErrRecipientRequired = errors.New("recipient is required")
+
+// Message is a notification to deliver. What Recipient holds depends on the
+// channel: an email address, a phone number, or an identifier passed on to
+// a webhook.
+type Message struct {
+	Recipient string
+	Subject   string
+	Body      string
+	// HTML is an optional HTML version of Body, used by channels that can
+	// display it.
+	HTML string
+}
+
+// Channel is a transport notifications can be sent over.
+type Channel interface {
+	// Name is the channel name clients ask for, e.g. "email".
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Registry holds the channels available to the service, keyed by name.
+type Registry struct {
+	mu       sync.RWMutex
+	channels map[string]Channel
+}
+
+func NewRegistry(channels ...Channel) *Registry {
+	r := &Registry{channels: make(map[string]Channel)}
+	for _, ch := range channels {
+		r.Register(ch)
+	}
+	return r
+}
+
+// Register adds ch, replacing any channel registered under the same name.
+func (r *Registry) Register(ch Channel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.channels[ch.Name()] = ch
+}
+
+// Get returns the channel registered under name.
+func (r *Registry) Get(name string) (Channel, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ch, ok := r.channels[name]
+	return ch, ok
+}
+
+// Names returns the names of all registered channels in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.channels))
+	for name := range r.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(NewLogChannel(nil), NewWebhookChannel("https://hooks.example.com", ""))
+
+	ch, ok := registry.Get("log")
+	assert.True(t, ok)
+	assert.Equal(t, "log", ch.Name())
+
+	_, ok = registry.Get("pigeon")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"log", "webhook"}, registry.Names())
+}
+
+func TestSMSChannel(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://sms.example.com").
+		Post("/messages").
+		MatchHeader("Authorization", "Bearer secret-key").
+		JSON(map[string]string{"from": "QueueMS", "to": "+77001234567", "text": "Your turn"}).
+		Reply(202)
+
+	ch := NewSMSChannel("https://sms.example.com/messages", "secret-key", "QueueMS")
+	err := ch.Send(context.Background(), Message{Recipient: "+77001234567", Body: "Your turn"})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestSMSChannelProviderError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://sms.example.com").
+		Post("/messages").
+		Reply(400).
+		BodyString(`{"error":"invalid number"}`)
+
+	ch := NewSMSChannel("https://sms.example.com/messages", "", "QueueMS")
+	err := ch.Send(context.Background(), Message{Recipient: "12", Body: "Your turn"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid number")
+
+	err = ch.Send(context.Background(), Message{Body: "Your turn"})
+	assert.ErrorIs(t, err, ErrRecipientRequired)
+}
+
+func TestWebhookChannel(t *testing.T) {
+	defer gock.Off()
+
+	var body []byte
+	var signature string
+	gock.New("https://hooks.example.com").
+		Post("/queue").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			signature = req.Header.Get(SignatureHeader)
+			var err error
+			body, err = io.ReadAll(req.Body)
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			return err == nil, err
+		}).
+		Reply(200)
+
+	ch := NewWebhookChannel("https://hooks.example.com/queue", "shared-secret")
+	err := ch.Send(context.Background(), Message{Recipient: "client-42", Subject: "Called", Body: "Counter 3"})
+	require.NoError(t, err)
+
+	var payload webhookPayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "client-42", payload.Recipient)
+	assert.Equal(t, "Counter 3", payload.Message)
+
+	mac := hmac.New(sha256.New, []byte("shared-secret"))
+	mac.Write(body)
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func TestLogChannel(t *testing.T) {
+	var buf bytes.Buffer
+	ch := NewLogChannel(log.New(&buf, "", 0))
+
+	err := ch.Send(context.Background(), Message{Recipient: "client@example.com", Subject: "Queue Notification", Body: "Hello"})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "client@example.com")
+	assert.Contains(t, buf.String(), "Hello")
+}
@@ -0,0 +1,44 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/mail.v2"
+)
+
+// EmailChannel sends notifications as email over SMTP. Messages with an HTML
+// version are sent as multipart/alternative with both parts.
+type EmailChannel struct {
+	dialer *mail.Dialer
+	from   string
+}
+
+func NewEmailChannel(dialer *mail.Dialer, from string) *EmailChannel {
+	return &EmailChannel{dialer: dialer, from: from}
+}
+
+func (c *EmailChannel) Name() string { return "email" }
+
+func (c *EmailChannel) Send(ctx context.Context, msg Message) error {
+	if msg.Recipient == "" {
+		return ErrRecipientRequired
+	}
+	if c.from == "" {
+		return fmt.Errorf("no sender address configured for email")
+	}
+
+	m := mail.NewMessage()
+	m.SetHeader("From", c.from)
+	m.SetHeader("To", msg.Recipient)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetBody("text/plain", msg.Body)
+	if msg.HTML != "" {
+		m.AddAlternative("text/html", msg.HTML)
+	}
+
+	if err := c.dialer.DialAndSend(m); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package channel
+
+import (
+	"context"
+	"log"
+)
+
+// LogChannel writes notifications to a logger instead of delivering them.
+// It is meant for local development.
+type LogChannel struct {
+	logger *log.Logger
+}
+
+// NewLogChannel returns a LogChannel writing to logger, or to the standard
+// logger when logger is nil.
+func NewLogChannel(logger *log.Logger) *LogChannel {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogChannel{logger: logger}
+}
+
+func (c *LogChannel) Name() string { return "log" }
+
+func (c *LogChannel) Send(ctx context.Context, msg Message) error {
+	c.logger.Printf("notification to %q: %s: %s", msg.Recipient, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,73 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SMSChannel sends text messages through an HTTP SMS provider. The message
+// is POSTed to the provider URL as JSON:
+//
+//	{"from": "QueueMS", "to": "+77001234567", "text": "..."}
+//
+// with the API key as a bearer token.
+type SMSChannel struct {
+	url    string
+	apiKey string
+	from   string
+	client *http.Client
+}
+
+func NewSMSChannel(url, apiKey, from string) *SMSChannel {
+	return &SMSChannel{url: url, apiKey: apiKey, from: from, client: http.DefaultClient}
+}
+
+func (c *SMSChannel) Name() string { return "sms" }
+
+func (c *SMSChannel) Send(ctx context.Context, msg Message) error {
+	if msg.Recipient == "" {
+		return ErrRecipientRequired
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"from": c.from,
+		"to":   msg.Recipient,
+		"text": msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	if err := doRequest(c.client, req); err != nil {
+		return fmt.Errorf("failed to send sms: %w", err)
+	}
+	return nil
+}
+
+// doRequest sends req and turns any non-2xx response into an error carrying
+// the start of the response body.
+func doRequest(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA256 of the request body
+// when the webhook is configured with a secret.
+const SignatureHeader = "X-QueueMS-Signature"
+
+// WebhookChannel POSTs notifications as JSON to a fixed URL, leaving the
+// delivery to the receiving system.
+type WebhookChannel struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// webhookPayload is the JSON body of a webhook call.
+type webhookPayload struct {
+	Recipient string    `json:"recipient"`
+	Subject   string    `json:"subject,omitempty"`
+	Message   string    `json:"message"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+func NewWebhookChannel(url, secret string) *WebhookChannel {
+	return &WebhookChannel{url: url, secret: secret, client: http.DefaultClient}
+}
+
+func (c *WebhookChannel) Name() string { return "webhook" }
+
+func (c *WebhookChannel) Send(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(webhookPayload{
+		Recipient: msg.Recipient,
+		Subject:   msg.Subject,
+		Message:   msg.Body,
+		SentAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		mac := hmac.New(sha256.New, []byte(c.secret))
+		mac.Write(payload)
+		req.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	if err := doRequest(c.client, req); err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	return nil
+}
@@ -1,26 +1,223 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"flag"
+	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
+	"gopkg.in/mail.v2"
 	"log"
 	"net"
+	"notification-service/channel"
+	"notification-service/migrations"
 	pb "notification-service/pb"
 	"notification-service/server"
+	"notification-service/smtpsink"
+	"notification-service/store"
+	"notification-service/templates"
+	"notification-service/worker"
+	"os"
+	"os/signal"
+	"queue-management-system/migrate"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 )
 
 const (
 	port = ":50052"
+
+	// drainTimeout bounds how long queued notifications may take to go out
+	// on shutdown.
+	drainTimeout = 30 * time.Second
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
+	devSMTP := flag.Bool("dev-smtp", false, "capture email in a local SMTP sink and log it instead of sending it")
+	flag.Parse()
+
+	var sink *smtpsink.Server
+	if *devSMTP {
+		var err error
+		sink, err = smtpsink.Start(logMail)
+		if err != nil {
+			log.Fatalf("failed to start SMTP sink: %v", err)
+		}
+		defer sink.Close()
+		log.Printf("dev SMTP sink listening at %s, email is not delivered", sink.Addr())
+	}
+
 	lis, err := net.Listen("tcp", port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
+	pool := worker.NewPool(poolConfig())
 	s := grpc.NewServer()
-	pb.RegisterNotificationServiceServer(s, server.NewNotificationService())
+	pb.RegisterNotificationServiceServer(s, server.NewNotificationService(channels(sink), loadTemplates(), openStore(), pool))
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		log.Println("shutting down, draining queued notifications")
+		s.GracefulStop()
+	}()
+
 	log.Printf("server listening at %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := pool.Shutdown(ctx); err != nil {
+		log.Printf("gave up draining notifications: %v", err)
+	}
+}
+
+// poolConfig sizes the delivery pool from WORKERS and QUEUE_SIZE and reads
+// per-channel limits from CHANNEL_RATE_LIMITS, e.g. "email=5,sms=1" for at
+// most five emails and one text message per second.
+func poolConfig() worker.Config {
+	cfg := worker.Config{Workers: 4, QueueSize: 100, RateLimits: make(map[string]float64)}
+	if v := os.Getenv("WORKERS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("invalid WORKERS %q", v)
+		}
+		cfg.Workers = n
+	}
+	if v := os.Getenv("QUEUE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("invalid QUEUE_SIZE %q", v)
+		}
+		cfg.QueueSize = n
+	}
+	if v := os.Getenv("CHANNEL_RATE_LIMITS"); v != "" {
+		for _, limit := range strings.Split(v, ",") {
+			name, rate, ok := strings.Cut(strings.TrimSpace(limit), "=")
+			perSecond, err := strconv.ParseFloat(rate, 64)
+			if !ok || err != nil || perSecond <= 0 {
+				log.Fatalf("invalid CHANNEL_RATE_LIMITS entry %q", limit)
+			}
+			cfg.RateLimits[name] = perSecond
+		}
+	}
+	return cfg
+}
+
+// channels registers the log channel and every channel configured through
+// the environment. Email goes to sink instead of SMTP_HOST when it is set.
+func channels(sink *smtpsink.Server) *channel.Registry {
+	registry := channel.NewRegistry(channel.NewLogChannel(nil))
+	registry.Register(emailChannel(sink))
+
+	if url := os.Getenv("SMS_PROVIDER_URL"); url != "" {
+		registry.Register(channel.NewSMSChannel(url, os.Getenv("SMS_API_KEY"), os.Getenv("SMS_SENDER")))
+	}
+	if url := os.Getenv("WEBHOOK_URL"); url != "" {
+		registry.Register(channel.NewWebhookChannel(url, os.Getenv("WEBHOOK_SECRET")))
+	}
+
+	log.Printf("notification channels: %v", registry.Names())
+	return registry
+}
+
+// emailChannel sends email through SMTP_HOST and SMTP_PORT, authenticating
+// with the Mailtrap credentials, or through sink when it is set.
+func emailChannel(sink *smtpsink.Server) *channel.EmailChannel {
+	from := os.Getenv("MAILTRAP_USER")
+	if sink != nil {
+		if from == "" {
+			from = "queuems@localhost"
+		}
+		return channel.NewEmailChannel(mail.NewDialer(sink.Host(), sink.Port(), "", ""), from)
+	}
+
+	smtpHost := os.Getenv("SMTP_HOST")
+	if smtpHost == "" {
+		smtpHost = "smtp.mailtrap.io"
+	}
+	smtpPort := 587
+	if v := os.Getenv("SMTP_PORT"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p <= 0 {
+			log.Fatalf("invalid SMTP_PORT %q", v)
+		}
+		smtpPort = p
+	}
+	dialer := mail.NewDialer(smtpHost, smtpPort, os.Getenv("MAILTRAP_USER"), os.Getenv("MAILTRAP_PASSWORD"))
+	return channel.NewEmailChannel(dialer, from)
+}
+
+// logMail prints an email captured in --dev-smtp mode.
+func logMail(m smtpsink.Message) {
+	log.Printf("captured email from %s to %v: %s\n%s", m.From, m.To, m.Subject, m.Text)
+}
+
+// loadTemplates returns the built-in templates, or the templates in
+// TEMPLATE_DIR when it is set.
+func loadTemplates() *templates.Store {
+	dir := os.Getenv("TEMPLATE_DIR")
+	if dir == "" {
+		return templates.Default()
+	}
+	store, err := templates.Load(os.DirFS(dir))
+	if err != nil {
+		log.Fatalf("failed to load templates from %s: %v", dir, err)
+	}
+	return store
+}
+
+// openStore logs notifications to the database at DATABASE_URL, or keeps
+// them in memory when it is not set.
+func openStore() store.Store {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		log.Println("DATABASE_URL is not set, the notification log is kept in memory")
+		return store.NewMemoryStore()
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	if os.Getenv("MIGRATE_ON_START") == "true" {
+		if err := newMigrator(db).Up(context.Background()); err != nil {
+			log.Fatalf("failed to migrate the database: %v", err)
+		}
+	}
+	return store.NewPostgresStore(db)
+}
+
+// runMigrate carries out the migrate subcommand against DATABASE_URL.
+func runMigrate(args []string) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		log.Fatal("migrate: DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+	if err := migrate.Run(context.Background(), newMigrator(db), args, os.Stdout); err != nil {
+		log.Fatalf("migrate: %v", err)
+	}
+}
+
+// newMigrator returns the migrator of the embedded notification migrations.
+func newMigrator(db *sql.DB) *migrate.Migrator {
+	m, err := migrate.New(db, "notification", migrations.FS)
+	if err != nil {
+		log.Fatalf("invalid migrations: %v", err)
+	}
+	return m
 }
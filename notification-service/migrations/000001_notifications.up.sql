@@ -0,0 +1,20 @@
+-- Every notification the service was asked to send, with the outcome of its
+-- last delivery attempt.
+CREATE TABLE notifications (
+    id SERIAL PRIMARY KEY,
+    event VARCHAR(50) NOT NULL DEFAULT '',
+    channel VARCHAR(20) NOT NULL,
+    recipient TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    error TEXT NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP,
+    CONSTRAINT notifications_status_check CHECK (status IN ('pending', 'sent', 'failed'))
+);
+
+CREATE INDEX idx_notifications_recipient ON notifications (recipient, created_at DESC);
+CREATE INDEX idx_notifications_created ON notifications (created_at DESC);
@@ -1,8 +1,29 @@
 package models
 
+import "time"
+
+// NotificationStatus is the delivery state of a notification.
+type NotificationStatus string
+
+const (
+	// StatusPending notifications have not been attempted yet.
+	StatusPending NotificationStatus = "pending"
+	StatusSent    NotificationStatus = "sent"
+	// StatusFailed notifications failed their last attempt.
+	StatusFailed NotificationStatus = "failed"
+)
+
 type Notification struct {
-	ID      int32  `json:"id"`
-	Message string `json:"message"`
-	Channel string `json:"channel"`
-	Status  string `json:"status"`
+	ID        int32              `json:"id"`
+	Event     string             `json:"event,omitempty"`
+	Message   string             `json:"message"`
+	Subject   string             `json:"subject"`
+	Channel   string             `json:"channel"`
+	Recipient string             `json:"recipient"`
+	Status    NotificationStatus `json:"status"`
+	Error     string             `json:"error,omitempty"`
+	Attempts  int32              `json:"attempts"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	SentAt    *time.Time         `json:"sent_at,omitempty"`
 }
@@ -2,23 +2,35 @@ package server
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
+	"notification-service/channel"
+	"notification-service/models"
 	"notification-service/pb"
-	"os"
+	"notification-service/store"
+	"notification-service/templates"
+	"notification-service/worker"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"gopkg.in/mail.v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// defaultSubject is used for notifications sent without a template.
+const defaultSubject = "Queue Notification"
+
 type NotificationServiceServer struct {
 	pb.UnimplementedNotificationServiceServer
-	dialer *mail.Dialer
+	channels  *channel.Registry
+	templates *templates.Store
+	store     store.Store
+	pool      *worker.Pool
 }
 
-func NewNotificationService(dialer *mail.Dialer) *NotificationServiceServer {
-	return &NotificationServiceServer{dialer: dialer}
+// NewNotificationService delivers notifications over channels on the given
+// worker pool, logging every one of them in store.
+func NewNotificationService(channels *channel.Registry, templates *templates.Store, store store.Store, pool *worker.Pool) *NotificationServiceServer {
+	return &NotificationServiceServer{channels: channels, templates: templates, store: store, pool: pool}
 }
 
 func (s *NotificationServiceServer) SendNotification(ctx context.Context, req *pb.SendNotificationRequest) (*pb.SendNotificationResponse, error) {
@@ -28,36 +40,182 @@ func (s *NotificationServiceServer) SendNotification(ctx context.Context, req *p
 
 	log.Printf("Sending %s notification: %s", req.Channel, req.Message)
 
-	if req.Channel == "email" {
-		if req.Email == "" {
-			return nil, status.Error(codes.InvalidArgument, "Email is required for email notifications")
+	n := &models.Notification{
+		Channel:   req.Channel,
+		Recipient: recipient(req.Channel, req.Email, req.Recipient),
+		Subject:   defaultSubject,
+		Message:   req.Message,
+	}
+	if err := s.send(ctx, n, "", req.Async); err != nil {
+		return nil, err
+	}
+	return sendResponse(n, req.Async), nil
+}
+
+// SendTemplatedNotification renders the templates of an event with the
+// request variables and sends the result.
+func (s *NotificationServiceServer) SendTemplatedNotification(ctx context.Context, req *pb.SendTemplatedNotificationRequest) (*pb.SendNotificationResponse, error) {
+	if req.Event == "" || req.Channel == "" {
+		return nil, status.Error(codes.InvalidArgument, "Event and channel are required")
+	}
+
+	rendered, err := s.templates.Render(req.Event, req.Locale, req.Variables)
+	if err != nil {
+		if errors.Is(err, templates.ErrUnknownEvent) {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown event %q", req.Event)
 		}
-		err := s.sendEmail(req.Email, req.Message)
-		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+		// Templates fail on missing or malformed variables.
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Printf("Sending %s notification for event %s", req.Channel, req.Event)
+
+	n := &models.Notification{
+		Event:     req.Event,
+		Channel:   req.Channel,
+		Recipient: recipient(req.Channel, req.Email, req.Recipient),
+		Subject:   rendered.Subject,
+		Message:   rendered.Text,
+	}
+	if err := s.send(ctx, n, rendered.HTML, req.Async); err != nil {
+		return nil, err
+	}
+	return sendResponse(n, req.Async), nil
+}
+
+func (s *NotificationServiceServer) GetNotification(ctx context.Context, req *pb.GetNotificationRequest) (*pb.Notification, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Notification ID is required")
+	}
+
+	n, err := s.store.Get(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "Notification not found")
 		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
+	return toPBNotification(n), nil
+}
 
-	// Implement other notification logic (e.g., send SMS, etc.)
+func (s *NotificationServiceServer) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Limit and offset must not be negative")
+	}
+	filter := store.Filter{
+		Recipient: req.Recipient,
+		Channel:   req.Channel,
+		Status:    models.NotificationStatus(req.Status),
+		Limit:     int(req.Limit),
+		Offset:    int(req.Offset),
+	}
+	if req.CreatedAfter != nil {
+		filter.From = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		filter.To = req.CreatedBefore.AsTime()
+	}
 
-	return &pb.SendNotificationResponse{Success: true, Message: "Notification sent successfully"}, nil
+	notifications, err := s.store.List(ctx, filter)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.ListNotificationsResponse{}
+	for _, n := range notifications {
+		resp.Notifications = append(resp.Notifications, toPBNotification(n))
+	}
+	return resp, nil
+}
+
+// send logs n in the store and hands its delivery to the worker pool. Unless
+// async is set, it waits for the delivery and reports its outcome. html is
+// the optional HTML version of the message.
+func (s *NotificationServiceServer) send(ctx context.Context, n *models.Notification, html string, async bool) error {
+	ch, ok := s.channels.Get(n.Channel)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "Unknown channel %q", n.Channel)
+	}
+	if err := s.store.Create(ctx, n); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	id := n.ID
+	msg := channel.Message{Recipient: n.Recipient, Subject: n.Subject, Body: n.Message, HTML: html}
+	result := make(chan error, 1)
+	err := s.pool.Submit(worker.Job{
+		Channel: n.Channel,
+		Run: func(jobCtx context.Context) {
+			sendErr := ch.Send(jobCtx, msg)
+			// The outcome is recorded even when the pool was cut short.
+			if err := s.store.RecordAttempt(context.Background(), id, sendErr); err != nil {
+				log.Printf("failed to record attempt of notification %d: %v", id, err)
+			}
+			result <- sendErr
+		},
+	})
+	if err != nil {
+		if err := s.store.RecordAttempt(ctx, id, err); err != nil {
+			log.Printf("failed to record attempt of notification %d: %v", id, err)
+		}
+		if errors.Is(err, worker.ErrQueueFull) {
+			return status.Error(codes.ResourceExhausted, "Too many notifications queued, try again later")
+		}
+		return status.Error(codes.Unavailable, "Notification service is shutting down")
+	}
+	if async {
+		return nil
+	}
+
+	select {
+	case sendErr := <-result:
+		if sendErr == nil {
+			return nil
+		}
+		if errors.Is(sendErr, channel.ErrRecipientRequired) {
+			return status.Errorf(codes.InvalidArgument, "Recipient is required for %s notifications", n.Channel)
+		}
+		return status.Error(codes.Internal, sendErr.Error())
+	case <-ctx.Done():
+		// The delivery carries on; its outcome shows up in GetNotification.
+		return status.FromContextError(ctx.Err()).Err()
+	}
 }
 
-func (s *NotificationServiceServer) sendEmail(to string, message string) error {
-	from := os.Getenv("MAILTRAP_USER")
-	if from == "" {
-		return fmt.Errorf("MAILTRAP_USER environment variable is not set")
+func sendResponse(n *models.Notification, async bool) *pb.SendNotificationResponse {
+	if async {
+		return &pb.SendNotificationResponse{Success: true, Message: "Notification queued", NotificationId: n.ID}
 	}
+	return &pb.SendNotificationResponse{Success: true, Message: "Notification sent successfully", NotificationId: n.ID}
+}
 
-	m := mail.NewMessage()
-	m.SetHeader("From", from)
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", "Queue Notification")
-	m.SetBody("text/plain", message)
+// recipient picks the address a request is delivered to. Email keeps using
+// the dedicated email field.
+func recipient(channelName, email, recipient string) string {
+	if channelName == "email" && email != "" {
+		return email
+	}
+	if recipient != "" {
+		return recipient
+	}
+	return email
+}
 
-	// Send the email using the provided dialer
-	if err := s.dialer.DialAndSend(m); err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+func toPBNotification(n *models.Notification) *pb.Notification {
+	notification := &pb.Notification{
+		Id:        n.ID,
+		Event:     n.Event,
+		Channel:   n.Channel,
+		Recipient: n.Recipient,
+		Subject:   n.Subject,
+		Message:   n.Message,
+		Status:    string(n.Status),
+		Error:     n.Error,
+		Attempts:  n.Attempts,
+		CreatedAt: timestamppb.New(n.CreatedAt),
+		UpdatedAt: timestamppb.New(n.UpdatedAt),
+	}
+	if n.SentAt != nil {
+		notification.SentAt = timestamppb.New(*n.SentAt)
 	}
-	return nil
+	return notification
 }
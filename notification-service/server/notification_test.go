@@ -5,41 +5,65 @@ import (
 	"net"
 	"os"
 	"testing"
+	"time"
 
+	"notification-service/channel"
 	"notification-service/pb"
+	"notification-service/smtpsink"
+	"notification-service/store"
+	"notification-service/templates"
+	"notification-service/worker"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"gopkg.in/h2non/gock.v1"
 	"gopkg.in/mail.v2"
 )
 
+// sink receives the mail sent by the tests in place of a real SMTP server.
+var sink *smtpsink.Server
+
+func TestMain(m *testing.M) {
+	var err error
+	sink, err = smtpsink.Start(nil)
+	if err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	sink.Close()
+	os.Exit(code)
+}
+
 func mockDialer() *mail.Dialer {
 	return &mail.Dialer{
-		Host:     "smtp.mailtrap.io",
-		Port:     587,
+		Host:     sink.Host(),
+		Port:     sink.Port(),
 		Username: os.Getenv("MAILTRAP_USER"),
 		Password: os.Getenv("MAILTRAP_PASSWORD"),
 	}
 }
 
+func newTestService() *NotificationServiceServer {
+	return NewNotificationService(mockChannels(), templates.Default(), store.NewMemoryStore(), worker.NewPool(worker.Config{Workers: 2, QueueSize: 10}))
+}
+
+func mockChannels() *channel.Registry {
+	return channel.NewRegistry(
+		channel.NewEmailChannel(mockDialer(), os.Getenv("MAILTRAP_USER")),
+		channel.NewLogChannel(nil),
+	)
+}
+
 func TestSendEmailNotification(t *testing.T) {
-	defer gock.Off()
+	sink.Reset()
 
 	// Ensure environment variables are set
-	os.Setenv("MAILTRAP_USER", "ernar")
+	os.Setenv("MAILTRAP_USER", "ernar@example.com")
 	os.Setenv("MAILTRAP_PASSWORD", "password")
 
-	// Mock Mailtrap API response
-	gock.New("https://smtp.mailtrap.io").
-		Post("/api/v1/inboxes").
-		Reply(200).
-		JSON(map[string]string{"status": "success"})
-
-	dialer := mockDialer()
-	server := NewNotificationService(dialer)
+	server := newTestService()
 	req := &pb.SendNotificationRequest{
 		Message: "Test email message",
 		Channel: "email",
@@ -47,16 +71,50 @@ func TestSendEmailNotification(t *testing.T) {
 	}
 
 	_, err := server.SendNotification(context.Background(), req)
-	assert.NoError(t, err)
+	require.NoError(t, err)
+
+	messages := sink.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "ernar@example.com", messages[0].From)
+	assert.Equal(t, []string{"client@example.com"}, messages[0].To)
+	assert.Equal(t, "ernar@example.com", messages[0].Username)
+	assert.Equal(t, "Queue Notification", messages[0].Subject)
+	assert.Equal(t, "Test email message", messages[0].Text)
+}
+
+func TestSendTemplatedEmail(t *testing.T) {
+	sink.Reset()
+	server := newTestService()
+
+	req := &pb.SendTemplatedNotificationRequest{
+		Event:   "client_called",
+		Locale:  "en",
+		Channel: "email",
+		Email:   "client@example.com",
+		Variables: map[string]string{
+			"client_name":   "Dias Ermek",
+			"ticket_number": "A-042",
+			"queue_name":    "Cash desk",
+			"counter":       "3",
+		},
+	}
+	_, err := server.SendTemplatedNotification(context.Background(), req)
+	require.NoError(t, err)
+
+	messages := sink.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"client@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].Subject, "A-042")
+	assert.Contains(t, messages[0].Text, "counter 3")
+	assert.Contains(t, messages[0].HTML, "<strong>")
 }
 
 // Test invalid arguments
 func TestSendNotificationInvalidArgs(t *testing.T) {
-	os.Setenv("MAILTRAP_USER", "ernar")
+	os.Setenv("MAILTRAP_USER", "ernar@example.com")
 	os.Setenv("MAILTRAP_PASSWORD", "password")
 
-	dialer := mockDialer()
-	server := NewNotificationService(dialer)
+	server := newTestService()
 
 	req := &pb.SendNotificationRequest{
 		Channel: "email",
@@ -75,13 +133,151 @@ func TestSendNotificationInvalidArgs(t *testing.T) {
 	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
+func TestSendNotificationUnknownChannel(t *testing.T) {
+	server := newTestService()
+
+	req := &pb.SendNotificationRequest{
+		Message: "Test message",
+		Channel: "pigeon",
+		Email:   "client@example.com",
+	}
+	_, err := server.SendNotification(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, `Unknown channel "pigeon"`, status.Convert(err).Message())
+}
+
+func TestSendLogNotification(t *testing.T) {
+	server := newTestService()
+
+	req := &pb.SendNotificationRequest{
+		Message:   "Test message",
+		Channel:   "log",
+		Recipient: "client-42",
+	}
+	resp, err := server.SendNotification(context.Background(), req)
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+}
+
+func TestSendTemplatedNotification(t *testing.T) {
+	server := newTestService()
+
+	req := &pb.SendTemplatedNotificationRequest{
+		Event:     "client_called",
+		Locale:    "en",
+		Channel:   "log",
+		Recipient: "client-42",
+		Variables: map[string]string{
+			"client_name":   "Dias Ermek",
+			"ticket_number": "A-042",
+			"queue_name":    "Cash desk",
+			"counter":       "3",
+		},
+	}
+	resp, err := server.SendTemplatedNotification(context.Background(), req)
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+
+	req.Event = "client_teleported"
+	_, err = server.SendTemplatedNotification(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	req.Event = "client_called"
+	req.Variables = nil
+	_, err = server.SendTemplatedNotification(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestNotificationLog(t *testing.T) {
+	server := newTestService()
+	ctx := context.Background()
+
+	resp, err := server.SendNotification(ctx, &pb.SendNotificationRequest{
+		Message:   "Test message",
+		Channel:   "log",
+		Recipient: "client-42",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, resp.NotificationId)
+
+	notification, err := server.GetNotification(ctx, &pb.GetNotificationRequest{Id: resp.NotificationId})
+	require.NoError(t, err)
+	assert.Equal(t, "sent", notification.Status)
+	assert.Equal(t, "client-42", notification.Recipient)
+	assert.Equal(t, int32(1), notification.Attempts)
+	assert.NotNil(t, notification.SentAt)
+
+	// Failed attempts are logged as well.
+	_, err = server.SendNotification(ctx, &pb.SendNotificationRequest{Message: "Test message", Channel: "email"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	list, err := server.ListNotifications(ctx, &pb.ListNotificationsRequest{Status: "failed"})
+	require.NoError(t, err)
+	require.Len(t, list.Notifications, 1)
+	assert.Equal(t, "email", list.Notifications[0].Channel)
+	assert.NotEmpty(t, list.Notifications[0].Error)
+
+	list, err = server.ListNotifications(ctx, &pb.ListNotificationsRequest{Recipient: "client-42", Channel: "log"})
+	require.NoError(t, err)
+	require.Len(t, list.Notifications, 1)
+	assert.Equal(t, resp.NotificationId, list.Notifications[0].Id)
+
+	_, err = server.GetNotification(ctx, &pb.GetNotificationRequest{Id: 999})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// blockingChannel holds every delivery until release is closed.
+type blockingChannel struct {
+	release chan struct{}
+}
+
+func (c *blockingChannel) Name() string { return "blocking" }
+
+func (c *blockingChannel) Send(ctx context.Context, msg channel.Message) error {
+	<-c.release
+	return nil
+}
+
+func TestSendNotificationAsync(t *testing.T) {
+	blocking := &blockingChannel{release: make(chan struct{})}
+	pool := worker.NewPool(worker.Config{Workers: 1, QueueSize: 1})
+	server := NewNotificationService(channel.NewRegistry(blocking), templates.Default(), store.NewMemoryStore(), pool)
+	ctx := context.Background()
+
+	req := &pb.SendNotificationRequest{Message: "Test message", Channel: "blocking", Recipient: "client-42", Async: true}
+	first, err := server.SendNotification(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "Notification queued", first.Message)
+
+	notification, err := server.GetNotification(ctx, &pb.GetNotificationRequest{Id: first.NotificationId})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", notification.Status)
+
+	// One delivery is running and one is queued: the pool is full.
+	require.Eventually(t, func() bool {
+		_, err := server.SendNotification(ctx, req)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	_, err = server.SendNotification(ctx, req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	close(blocking.release)
+	require.NoError(t, pool.Shutdown(ctx))
+
+	notification, err = server.GetNotification(ctx, &pb.GetNotificationRequest{Id: first.NotificationId})
+	require.NoError(t, err)
+	assert.Equal(t, "sent", notification.Status)
+
+	_, err = server.SendNotification(ctx, req)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
 func TestSendNotificationIntegration(t *testing.T) {
-	os.Setenv("MAILTRAP_USER", "ernar")
+	os.Setenv("MAILTRAP_USER", "ernar@example.com")
 	os.Setenv("MAILTRAP_PASSWORD", "password")
 
 	server := grpc.NewServer()
-	dialer := mockDialer()
-	pb.RegisterNotificationServiceServer(server, NewNotificationService(dialer))
+	pb.RegisterNotificationServiceServer(server, newTestService())
 
 	lis, err := net.Listen("tcp", ":0") // dynamically allocate a port
 	assert.NoError(t, err)
@@ -100,7 +296,9 @@ func TestSendNotificationIntegration(t *testing.T) {
 		Email:   "client@example.com",
 	}
 
+	sink.Reset()
 	resp, err := client.SendNotification(context.Background(), req)
 	assert.NoError(t, err)
 	assert.True(t, resp.Success)
+	assert.Len(t, sink.Messages(), 1)
 }
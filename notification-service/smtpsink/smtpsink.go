@@ -0,0 +1,383 @@
+// Package smtpsink is an SMTP server that accepts every message and keeps it
+// in memory instead of delivering it. Tests point the email channel at it to
+// assert on the mail that was actually sent, and the service uses it in
+// --dev-smtp mode so no real mail leaves a development machine.
+package smtpsink
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a message received by the sink.
+type Message struct {
+	// From and To are the envelope sender and recipients.
+	From string
+	To   []string
+	// Username is the name the client authenticated with, if it did.
+	Username string
+	// Data is the message as received, headers included.
+	Data []byte
+
+	Header  mail.Header
+	Subject string
+	// Text and HTML are the decoded plain text and HTML bodies. Single part
+	// messages only set one of them.
+	Text string
+	HTML string
+
+	ReceivedAt time.Time
+}
+
+// Server is a running SMTP sink.
+type Server struct {
+	listener  net.Listener
+	onMessage func(Message)
+
+	mu       sync.Mutex
+	messages []Message
+	received chan struct{}
+	// conns are the open sessions, closed by Close so an idle client cannot
+	// keep the sink running.
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Start listens on a random localhost port and serves SMTP until Close is
+// called. onMessage, when not nil, is called for every received message.
+func Start(onMessage func(Message)) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{listener: listener, onMessage: onMessage, received: make(chan struct{}), conns: make(map[net.Conn]struct{})}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the address the sink listens on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Host returns the host the sink listens on.
+func (s *Server) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+// Port returns the port the sink listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Messages returns the messages received so far, oldest first.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// WaitForMessages waits until at least n messages were received and returns
+// them, or returns an error after timeout.
+func (s *Server) WaitForMessages(n int, timeout time.Duration) ([]Message, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		s.mu.Lock()
+		messages, received := append([]Message(nil), s.messages...), s.received
+		s.mu.Unlock()
+		if len(messages) >= n {
+			return messages, nil
+		}
+		select {
+		case <-received:
+		case <-deadline.C:
+			return messages, fmt.Errorf("received %d messages, want %d", len(messages), n)
+		}
+	}
+}
+
+// Reset forgets the received messages.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = nil
+}
+
+// Close stops the sink, ending the open sessions, and waits for them.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		if !s.track(conn) {
+			conn.Close()
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer s.untrack(conn)
+			s.session(conn)
+		}()
+	}
+}
+
+// track records an open session, unless the sink is closing.
+func (s *Server) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	return true
+}
+
+func (s *Server) untrack(conn net.Conn) {
+	s.mu.Lock()
+	delete(s.conns, conn)
+	s.mu.Unlock()
+}
+
+func (s *Server) store(m Message) {
+	s.mu.Lock()
+	s.messages = append(s.messages, m)
+	close(s.received)
+	s.received = make(chan struct{})
+	s.mu.Unlock()
+
+	if s.onMessage != nil {
+		s.onMessage(m)
+	}
+}
+
+// session speaks the server side of SMTP on conn. Every sender, recipient
+// and credential is accepted.
+func (s *Server) session(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	reply := func(format string, args ...interface{}) {
+		fmt.Fprintf(w, format+"\r\n", args...)
+		w.Flush()
+	}
+
+	reply("220 localhost smtpsink ready")
+	var envelope Message
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			reply("250 localhost")
+		case "EHLO":
+			reply("250-localhost")
+			reply("250-8BITMIME")
+			reply("250 AUTH PLAIN LOGIN")
+		case "AUTH":
+			username, err := authenticate(r, reply, arg)
+			if err != nil {
+				reply("501 %v", err)
+				continue
+			}
+			envelope.Username = username
+			reply("235 Authentication successful")
+		case "MAIL":
+			envelope.From = address(arg)
+			envelope.To = nil
+			reply("250 OK")
+		case "RCPT":
+			envelope.To = append(envelope.To, address(arg))
+			reply("250 OK")
+		case "DATA":
+			if len(envelope.To) == 0 {
+				reply("503 RCPT first")
+				continue
+			}
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data, err := readData(r)
+			if err != nil {
+				return
+			}
+			m := envelope
+			m.Data = data
+			m.ReceivedAt = time.Now()
+			parse(&m)
+			s.store(m)
+			envelope = Message{Username: envelope.Username}
+			reply("250 OK")
+		case "RSET":
+			envelope = Message{Username: envelope.Username}
+			reply("250 OK")
+		case "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readData reads a DATA section up to the terminating dot line, undoing the
+// dot stuffing.
+func readData(r *bufio.Reader) ([]byte, error) {
+	var data bytes.Buffer
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if line == "." {
+			return data.Bytes(), nil
+		}
+		data.WriteString(strings.TrimPrefix(line, "."))
+		data.WriteString("\r\n")
+	}
+}
+
+// authenticate runs an AUTH exchange and returns the username.
+func authenticate(r *bufio.Reader, reply func(string, ...interface{}), arg string) (string, error) {
+	mechanism, initial, _ := strings.Cut(arg, " ")
+	switch strings.ToUpper(mechanism) {
+	case "PLAIN":
+		if initial == "" {
+			reply("334 ")
+			line, err := readLine(r)
+			if err != nil {
+				return "", err
+			}
+			initial = line
+		}
+		decoded, err := base64.StdEncoding.DecodeString(initial)
+		if err != nil {
+			return "", err
+		}
+		// authzid NUL authcid NUL password
+		parts := strings.Split(string(decoded), "\x00")
+		if len(parts) != 3 {
+			return "", fmt.Errorf("malformed PLAIN credentials")
+		}
+		return parts[1], nil
+	case "LOGIN":
+		reply("334 %s", base64.StdEncoding.EncodeToString([]byte("Username:")))
+		line, err := readLine(r)
+		if err != nil {
+			return "", err
+		}
+		username, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return "", err
+		}
+		reply("334 %s", base64.StdEncoding.EncodeToString([]byte("Password:")))
+		if _, err := readLine(r); err != nil {
+			return "", err
+		}
+		return string(username), nil
+	}
+	return "", fmt.Errorf("unsupported mechanism %q", mechanism)
+}
+
+// address extracts the address from a MAIL FROM:<...> or RCPT TO:<...>
+// argument.
+func address(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+	return strings.Trim(addr, "<>")
+}
+
+// parse fills the header and decoded bodies of m from its data. Messages
+// that cannot be parsed keep only their raw data.
+func parse(m *Message) {
+	// The line break ending the DATA section belongs to the transport, not
+	// to the last line of the body.
+	msg, err := mail.ReadMessage(bytes.NewReader(bytes.TrimSuffix(m.Data, []byte("\r\n"))))
+	if err != nil {
+		return
+	}
+	m.Header = msg.Header
+	m.Subject, err = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		m.Subject = msg.Header.Get("Subject")
+	}
+	collectParts(m, msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+}
+
+func collectParts(m *Message, contentType, encoding string, body io.Reader) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				return
+			}
+			collectParts(m, part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+		}
+	}
+
+	content, err := io.ReadAll(decode(encoding, body))
+	if err != nil {
+		return
+	}
+	switch mediaType {
+	case "text/plain":
+		m.Text = string(content)
+	case "text/html":
+		m.HTML = string(content)
+	}
+}
+
+func decode(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(encoding) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
@@ -0,0 +1,106 @@
+package smtpsink
+
+import (
+	"bufio"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSink(t *testing.T) {
+	received := make(chan Message, 1)
+	sink, err := Start(func(m Message) { received <- m })
+	require.NoError(t, err)
+	defer sink.Close()
+
+	body := strings.Join([]string{
+		"From: desk@example.com",
+		"To: client@example.com",
+		"Subject: =?UTF-8?q?Ticket_A-042?=",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		"Your turn.",
+		".leading dot",
+		"",
+	}, "\r\n")
+	auth := smtp.PlainAuth("", "desk", "secret", sink.Host())
+	err = smtp.SendMail(sink.Addr(), auth, "desk@example.com", []string{"client@example.com", "copy@example.com"}, []byte(body))
+	require.NoError(t, err)
+
+	m := <-received
+	assert.Equal(t, "desk@example.com", m.From)
+	assert.Equal(t, []string{"client@example.com", "copy@example.com"}, m.To)
+	assert.Equal(t, "desk", m.Username)
+	assert.Equal(t, "Ticket A-042", m.Subject)
+	assert.Equal(t, "Your turn.\r\n.leading dot", m.Text)
+	assert.Empty(t, m.HTML)
+
+	messages, err := sink.WaitForMessages(1, time.Second)
+	require.NoError(t, err)
+	assert.Len(t, messages, 1)
+
+	sink.Reset()
+	assert.Empty(t, sink.Messages())
+	_, err = sink.WaitForMessages(1, 10*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestSinkMultipart(t *testing.T) {
+	sink, err := Start(nil)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	body := strings.Join([]string{
+		"Subject: Called",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/alternative; boundary="b"`,
+		"",
+		"--b",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"Proceed to counter =333.",
+		"--b",
+		"Content-Type: text/html; charset=UTF-8",
+		"Content-Transfer-Encoding: base64",
+		"",
+		"PHA+UHJvY2VlZDwvcD4=",
+		"--b--",
+		"",
+	}, "\r\n")
+	err = smtp.SendMail(sink.Addr(), nil, "desk@example.com", []string{"client@example.com"}, []byte(body))
+	require.NoError(t, err)
+
+	messages := sink.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Called", messages[0].Subject)
+	assert.Equal(t, "Proceed to counter 33.", messages[0].Text)
+	assert.Equal(t, "<p>Proceed</p>", messages[0].HTML)
+}
+
+func TestSinkCloseEndsIdleSessions(t *testing.T) {
+	sink, err := Start(nil)
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", sink.Addr())
+	require.NoError(t, err)
+	defer conn.Close()
+	greeting, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(greeting, "220"), greeting)
+
+	// The client goes quiet without quitting.
+	closed := make(chan error, 1)
+	go func() { closed <- sink.Close() }()
+	select {
+	case err := <-closed:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Close waited for an idle session")
+	}
+}
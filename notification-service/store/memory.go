@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"notification-service/models"
+)
+
+// MemoryStore keeps notifications in memory. It is used when no database is
+// configured and in tests.
+type MemoryStore struct {
+	mu            sync.Mutex
+	notifications map[int32]*models.Notification
+	nextID        int32
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{notifications: make(map[int32]*models.Notification)}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, n *models.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	n.ID = s.nextID
+	n.Status = models.StatusPending
+	n.CreatedAt = time.Now()
+	n.UpdatedAt = n.CreatedAt
+	stored := *n
+	s.notifications[n.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) RecordAttempt(ctx context.Context, id int32, sendErr error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.notifications[id]
+	if !ok {
+		return ErrNotFound
+	}
+	n.Attempts++
+	n.UpdatedAt = time.Now()
+	if sendErr == nil {
+		n.Status = models.StatusSent
+		n.Error = ""
+		sentAt := n.UpdatedAt
+		n.SentAt = &sentAt
+	} else {
+		n.Status = models.StatusFailed
+		n.Error = sendErr.Error()
+	}
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id int32) (*models.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.notifications[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *n
+	return &copied, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, filter Filter) ([]*models.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matches []*models.Notification
+	for _, n := range s.notifications {
+		if filter.matches(n) {
+			copied := *n
+			matches = append(matches, &copied)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].CreatedAt.Equal(matches[j].CreatedAt) {
+			return matches[i].CreatedAt.After(matches[j].CreatedAt)
+		}
+		return matches[i].ID > matches[j].ID
+	})
+
+	if filter.Offset >= len(matches) {
+		return nil, nil
+	}
+	matches = matches[filter.Offset:]
+	if len(matches) > filter.limit() {
+		matches = matches[:filter.limit()]
+	}
+	return matches, nil
+}
+
+func (f Filter) matches(n *models.Notification) bool {
+	switch {
+	case f.Recipient != "" && n.Recipient != f.Recipient,
+		f.Channel != "" && n.Channel != f.Channel,
+		f.Status != "" && n.Status != f.Status,
+		!f.From.IsZero() && n.CreatedAt.Before(f.From),
+		!f.To.IsZero() && !n.CreatedAt.Before(f.To):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"notification-service/models"
+)
+
+const notificationColumns = "id, event, channel, recipient, subject, message, status, error, attempts, created_at, updated_at, sent_at"
+
+// PostgresStore keeps notifications in the notifications table.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Create(ctx context.Context, n *models.Notification) error {
+	n.Status = models.StatusPending
+	return s.db.QueryRowContext(ctx, `
+		INSERT INTO notifications (event, channel, recipient, subject, message, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`,
+		n.Event, n.Channel, n.Recipient, n.Subject, n.Message, n.Status).
+		Scan(&n.ID, &n.CreatedAt, &n.UpdatedAt)
+}
+
+func (s *PostgresStore) RecordAttempt(ctx context.Context, id int32, sendErr error) error {
+	var res sql.Result
+	var err error
+	if sendErr == nil {
+		res, err = s.db.ExecContext(ctx, `
+			UPDATE notifications SET status = 'sent', error = '', attempts = attempts + 1,
+				updated_at = NOW(), sent_at = NOW()
+			WHERE id = $1`, id)
+	} else {
+		res, err = s.db.ExecContext(ctx, `
+			UPDATE notifications SET status = 'failed', error = $2, attempts = attempts + 1, updated_at = NOW()
+			WHERE id = $1`, id, sendErr.Error())
+	}
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStore) Get(ctx context.Context, id int32) (*models.Notification, error) {
+	n, err := scanNotification(s.db.QueryRowContext(ctx, "SELECT "+notificationColumns+" FROM notifications WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return n, err
+}
+
+func (s *PostgresStore) List(ctx context.Context, filter Filter) ([]*models.Notification, error) {
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Recipient != "" {
+		where("recipient = $%d", filter.Recipient)
+	}
+	if filter.Channel != "" {
+		where("channel = $%d", filter.Channel)
+	}
+	if filter.Status != "" {
+		where("status = $%d", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		where("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("created_at < $%d", filter.To)
+	}
+
+	query := "SELECT " + notificationColumns + " FROM notifications"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.limit(), filter.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanNotification(row rowScanner) (*models.Notification, error) {
+	var n models.Notification
+	var sentAt sql.NullTime
+	err := row.Scan(&n.ID, &n.Event, &n.Channel, &n.Recipient, &n.Subject, &n.Message, &n.Status, &n.Error,
+		&n.Attempts, &n.CreatedAt, &n.UpdatedAt, &sentAt)
+	if err != nil {
+		return nil, err
+	}
+	if sentAt.Valid {
+		n.SentAt = &sentAt.Time
+	}
+	return &n, nil
+}
@@ -0,0 +1,48 @@
+// Package store keeps the log of notifications sent by the service.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"notification-service/models"
+)
+
+// ErrNotFound is returned for notifications that do not exist.
+var ErrNotFound = errors.New("notification not found")
+
+// DefaultListLimit caps List results when the filter sets no limit.
+const DefaultListLimit = 50
+
+// Filter selects notifications in List. Zero fields match everything.
+type Filter struct {
+	Recipient string
+	Channel   string
+	Status    models.NotificationStatus
+	// From and To bound the creation time, From inclusive and To exclusive.
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
+
+// Store records notifications and the outcome of their delivery attempts.
+type Store interface {
+	// Create stores a new pending notification and sets its ID and
+	// timestamps.
+	Create(ctx context.Context, n *models.Notification) error
+	// RecordAttempt counts a delivery attempt of a notification, marking it
+	// sent when sendErr is nil and failed otherwise.
+	RecordAttempt(ctx context.Context, id int32, sendErr error) error
+	Get(ctx context.Context, id int32) (*models.Notification, error)
+	// List returns the matching notifications, newest first.
+	List(ctx context.Context, filter Filter) ([]*models.Notification, error)
+}
+
+func (f Filter) limit() int {
+	if f.Limit <= 0 {
+		return DefaultListLimit
+	}
+	return f.Limit
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"notification-service/models"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	sent := &models.Notification{Channel: "email", Recipient: "dias@example.com", Message: "Your turn"}
+	require.NoError(t, s.Create(ctx, sent))
+	require.NoError(t, s.RecordAttempt(ctx, sent.ID, nil))
+
+	failed := &models.Notification{Channel: "sms", Recipient: "+77001234567", Message: "Your turn"}
+	require.NoError(t, s.Create(ctx, failed))
+	require.NoError(t, s.RecordAttempt(ctx, failed.ID, errors.New("provider down")))
+	require.NoError(t, s.RecordAttempt(ctx, failed.ID, errors.New("provider still down")))
+
+	got, err := s.Get(ctx, sent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusSent, got.Status)
+	assert.Equal(t, int32(1), got.Attempts)
+	assert.NotNil(t, got.SentAt)
+
+	got, err = s.Get(ctx, failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusFailed, got.Status)
+	assert.Equal(t, int32(2), got.Attempts)
+	assert.Equal(t, "provider still down", got.Error)
+
+	_, err = s.Get(ctx, 42)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, s.RecordAttempt(ctx, 42, nil), ErrNotFound)
+
+	list, err := s.List(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, failed.ID, list[0].ID, "newest first")
+
+	list, err = s.List(ctx, Filter{Recipient: "dias@example.com"})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, sent.ID, list[0].ID)
+
+	list, err = s.List(ctx, Filter{Channel: "sms", Status: models.StatusFailed})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	list, err = s.List(ctx, Filter{To: got.CreatedAt.Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	list, err = s.List(ctx, Filter{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, sent.ID, list[0].ID)
+}
+
+func TestPostgresStoreList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT .* FROM notifications WHERE recipient = \\$1 AND status = \\$2 AND created_at >= \\$3 "+
+		"ORDER BY created_at DESC, id DESC LIMIT \\$4 OFFSET \\$5").
+		WithArgs("dias@example.com", models.StatusFailed, from, DefaultListLimit, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event", "channel", "recipient", "subject", "message", "status",
+			"error", "attempts", "created_at", "updated_at", "sent_at"}).
+			AddRow(7, "", "email", "dias@example.com", "Queue Notification", "Your turn", "failed",
+				"connection refused", 3, from, from, nil))
+
+	s := NewPostgresStore(db)
+	list, err := s.List(context.Background(), Filter{Recipient: "dias@example.com", Status: models.StatusFailed, From: from})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, int32(7), list[0].ID)
+	assert.Equal(t, "connection refused", list[0].Error)
+	assert.Nil(t, list[0].SentAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStoreRecordAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE notifications SET status = 'failed'").WithArgs(int32(7), "smtp down").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE notifications SET status = 'sent'").WithArgs(int32(8)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	s := NewPostgresStore(db)
+	assert.NoError(t, s.RecordAttempt(context.Background(), 7, errors.New("smtp down")))
+	assert.ErrorIs(t, s.RecordAttempt(context.Background(), 8, nil), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,157 @@
+// Package templates renders notifications from templates keyed by event and
+// locale. Each template file is named <locale>/<event>.tmpl and defines a
+// "subject" and a "text" template, plus an optional "html" template for the
+// HTML part of emails. The text templates use text/template, the HTML one
+// html/template so variables are escaped.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+)
+
+// DefaultLocale is used when no template exists for the requested locale.
+const DefaultLocale = "en"
+
+// ErrUnknownEvent is returned when no template exists for an event.
+var ErrUnknownEvent = errors.New("unknown event")
+
+//go:embed defaults
+var defaults embed.FS
+
+var funcs = map[string]interface{}{
+	"minutes": minutes,
+}
+
+// Rendered is a notification rendered from a template.
+type Rendered struct {
+	Subject string
+	Text    string
+	// HTML is empty when the template has no HTML part.
+	HTML string
+}
+
+type key struct {
+	event  string
+	locale string
+}
+
+type set struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Store holds the templates of every event and locale.
+type Store struct {
+	sets map[key]*set
+}
+
+// Default returns the templates shipped with the service.
+func Default() *Store {
+	sub, err := fs.Sub(defaults, "defaults")
+	if err != nil {
+		panic(err)
+	}
+	store, err := Load(sub)
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
+
+// Load reads the templates in fsys, laid out as <locale>/<event>.tmpl.
+func Load(fsys fs.FS) (*Store, error) {
+	files, err := fs.Glob(fsys, "*/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{sets: make(map[key]*set)}
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		k := key{
+			event:  strings.TrimSuffix(path.Base(file), ".tmpl"),
+			locale: path.Dir(file),
+		}
+
+		text, err := texttemplate.New(file).Funcs(funcs).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, err
+		}
+		if text.Lookup("subject") == nil || text.Lookup("text") == nil {
+			return nil, fmt.Errorf("%s: subject and text templates are required", file)
+		}
+		s := &set{text: text}
+		if text.Lookup("html") != nil {
+			s.html, err = htmltemplate.New(file).Funcs(funcs).Option("missingkey=error").Parse(string(content))
+			if err != nil {
+				return nil, err
+			}
+		}
+		store.sets[k] = s
+	}
+	return store, nil
+}
+
+// Render renders the templates of event in locale. A regional locale such
+// as "ru-KZ" falls back to "ru" and then to DefaultLocale.
+func (s *Store) Render(event, locale string, vars map[string]string) (*Rendered, error) {
+	set := s.lookup(event, locale)
+	if set == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownEvent, event)
+	}
+
+	var rendered Rendered
+	var buf bytes.Buffer
+	if err := set.text.ExecuteTemplate(&buf, "subject", vars); err != nil {
+		return nil, err
+	}
+	rendered.Subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := set.text.ExecuteTemplate(&buf, "text", vars); err != nil {
+		return nil, err
+	}
+	rendered.Text = buf.String()
+
+	if set.html != nil {
+		buf.Reset()
+		if err := set.html.ExecuteTemplate(&buf, "html", vars); err != nil {
+			return nil, err
+		}
+		rendered.HTML = buf.String()
+	}
+	return &rendered, nil
+}
+
+func (s *Store) lookup(event, locale string) *set {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	for _, l := range []string{locale, strings.SplitN(locale, "-", 2)[0], DefaultLocale} {
+		if set, ok := s.sets[key{event: event, locale: l}]; ok {
+			return set
+		}
+	}
+	return nil
+}
+
+// minutes turns a number of seconds into whole minutes, rounding up so an
+// estimate never reads as zero.
+func minutes(seconds string) (string, error) {
+	n, err := strconv.ParseFloat(seconds, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid number of seconds %q", seconds)
+	}
+	return strconv.Itoa(int(math.Max(1, math.Ceil(n/60)))), nil
+}
@@ -0,0 +1,93 @@
+package templates
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var calledVars = map[string]string{
+	"client_name":   "Dias",
+	"ticket_number": "A-042",
+	"queue_name":    "Cash desk",
+	"counter":       "3",
+}
+
+func TestRender(t *testing.T) {
+	store := Default()
+
+	rendered, err := store.Render("client_called", "en", calledVars)
+	require.NoError(t, err)
+	assert.Equal(t, "Ticket A-042 is being called", rendered.Subject)
+	assert.Contains(t, rendered.Text, "Please proceed to counter 3.")
+	assert.Contains(t, rendered.HTML, "<strong>A-042</strong>")
+}
+
+func TestRenderLocaleFallback(t *testing.T) {
+	store := Default()
+
+	// A regional locale uses its language.
+	rendered, err := store.Render("client_called", "ru-KZ", calledVars)
+	require.NoError(t, err)
+	assert.Equal(t, "Талон A-042 вызван", rendered.Subject)
+
+	// Unknown locales use the default one.
+	rendered, err = store.Render("client_called", "de", calledVars)
+	require.NoError(t, err)
+	assert.Equal(t, "Ticket A-042 is being called", rendered.Subject)
+}
+
+func TestRenderETA(t *testing.T) {
+	rendered, err := Default().Render("client_near_front", "en", map[string]string{
+		"client_name":   "Dias",
+		"ticket_number": "A-042",
+		"queue_name":    "Cash desk",
+		"clients_ahead": "2",
+		"eta_seconds":   "130",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, rendered.Text, "2 clients are ahead of you, about 3 minutes left")
+}
+
+func TestRenderErrors(t *testing.T) {
+	store := Default()
+
+	_, err := store.Render("client_teleported", "en", calledVars)
+	assert.ErrorIs(t, err, ErrUnknownEvent)
+
+	// Every variable a template uses must be given.
+	_, err = store.Render("client_called", "en", map[string]string{"client_name": "Dias"})
+	assert.Error(t, err)
+}
+
+func TestRenderEscapesHTML(t *testing.T) {
+	vars := map[string]string{}
+	for k, v := range calledVars {
+		vars[k] = v
+	}
+	vars["client_name"] = "<script>alert(1)</script>"
+
+	rendered, err := Default().Render("client_called", "en", vars)
+	require.NoError(t, err)
+	assert.Contains(t, rendered.Text, "<script>")
+	assert.NotContains(t, rendered.HTML, "<script>")
+}
+
+func TestLoad(t *testing.T) {
+	store, err := Load(fstest.MapFS{
+		"en/welcome.tmpl": {Data: []byte(`{{define "subject"}}Welcome{{end}}{{define "text"}}Hi {{.client_name}}{{end}}`)},
+	})
+	require.NoError(t, err)
+
+	rendered, err := store.Render("welcome", "en", map[string]string{"client_name": "Dias"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hi Dias", rendered.Text)
+	assert.Empty(t, rendered.HTML)
+
+	_, err = Load(fstest.MapFS{
+		"en/broken.tmpl": {Data: []byte(`{{define "text"}}No subject{{end}}`)},
+	})
+	assert.Error(t, err)
+}
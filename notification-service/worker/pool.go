@@ -0,0 +1,192 @@
+// Package worker runs notification deliveries on a bounded pool of
+// goroutines, so slow channels cannot tie up RPC goroutines and the number of
+// concurrent deliveries stays under control.
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueFull is returned by Submit when no more jobs can be queued.
+	ErrQueueFull = errors.New("job queue is full")
+	// ErrClosed is returned by Submit once Shutdown was called.
+	ErrClosed = errors.New("worker pool is shut down")
+)
+
+// Job is a delivery over a channel. Run is called with a context that is
+// cancelled only when the pool gives up draining on shutdown.
+type Job struct {
+	Channel string
+	Run     func(ctx context.Context)
+}
+
+// Config sizes a Pool.
+type Config struct {
+	Workers   int
+	QueueSize int
+	// RateLimits caps the deliveries per second of individual channels.
+	// Channels without an entry are not limited. The jobs of a limited
+	// channel wait in a queue of their own, also QueueSize long, so they do
+	// not hold up the other channels.
+	RateLimits map[string]float64
+}
+
+// Pool runs submitted jobs on a fixed number of workers.
+type Pool struct {
+	jobs    chan Job
+	lanes   map[string]*lane
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+	// releasing tracks the lanes still handing jobs to the workers.
+	releasing sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// lane queues the jobs of a rate-limited channel and hands them to the
+// workers no faster than its limiter allows.
+type lane struct {
+	jobs    chan Job
+	limiter *limiter
+}
+
+// NewPool starts cfg.Workers workers.
+func NewPool(cfg Config) *Pool {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		jobs:   make(chan Job, cfg.QueueSize),
+		lanes:  make(map[string]*lane),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for channel, perSecond := range cfg.RateLimits {
+		if perSecond > 0 {
+			l := &lane{
+				jobs:    make(chan Job, cfg.QueueSize),
+				limiter: &limiter{interval: time.Duration(float64(time.Second) / perSecond)},
+			}
+			p.lanes[channel] = l
+			p.releasing.Add(1)
+			go p.release(l)
+		}
+	}
+
+	p.workers.Add(cfg.Workers)
+	for i := 0; i < cfg.Workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues a job without blocking.
+func (p *Pool) Submit(job Job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	queue := p.jobs
+	if l := p.lanes[job.Channel]; l != nil {
+		queue = l.jobs
+	}
+	select {
+	case queue <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting jobs and waits for the queued ones to finish. If
+// ctx ends first, running jobs are cancelled and ctx's error is returned.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	first := !p.closed
+	if first {
+		p.closed = true
+		for _, l := range p.lanes {
+			close(l.jobs)
+		}
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		// The lanes hand their last jobs to the workers before the workers
+		// are told no more are coming.
+		p.releasing.Wait()
+		if first {
+			close(p.jobs)
+		}
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work() {
+	defer p.workers.Done()
+	for job := range p.jobs {
+		job.Run(p.ctx)
+	}
+}
+
+// release hands the jobs of a lane to the workers as its limiter allows.
+// Only the lane waits for the limiter, so no worker sits idle on it.
+func (p *Pool) release(l *lane) {
+	defer p.releasing.Done()
+	for job := range l.jobs {
+		// Waiting only fails when draining was cut short. The job still
+		// runs, with a cancelled context, so it can record the failure.
+		l.limiter.wait(p.ctx)
+		p.jobs <- job
+	}
+}
+
+// limiter spaces deliveries of a channel at least interval apart.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolRunsJobs(t *testing.T) {
+	pool := NewPool(Config{Workers: 3, QueueSize: 10})
+
+	var ran int32
+	for i := 0; i < 10; i++ {
+		require.NoError(t, pool.Submit(Job{Channel: "log", Run: func(ctx context.Context) {
+			atomic.AddInt32(&ran, 1)
+		}}))
+	}
+
+	// Shutdown drains everything that was queued.
+	require.NoError(t, pool.Shutdown(context.Background()))
+	assert.Equal(t, int32(10), atomic.LoadInt32(&ran))
+
+	err := pool.Submit(Job{Channel: "log", Run: func(ctx context.Context) {}})
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestPoolQueueFull(t *testing.T) {
+	pool := NewPool(Config{Workers: 1, QueueSize: 1})
+	release := make(chan struct{})
+	started := make(chan struct{})
+	block := Job{Channel: "email", Run: func(ctx context.Context) {
+		close(started)
+		<-release
+	}}
+
+	require.NoError(t, pool.Submit(block))
+	<-started
+	require.NoError(t, pool.Submit(Job{Channel: "email", Run: func(ctx context.Context) {}}))
+	assert.ErrorIs(t, pool.Submit(Job{Channel: "email", Run: func(ctx context.Context) {}}), ErrQueueFull)
+
+	close(release)
+	require.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestPoolShutdownTimeout(t *testing.T) {
+	pool := NewPool(Config{Workers: 1, QueueSize: 1})
+	cancelled := make(chan struct{})
+	require.NoError(t, pool.Submit(Job{Channel: "email", Run: func(ctx context.Context) {
+		<-ctx.Done()
+		close(cancelled)
+	}}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, pool.Shutdown(ctx), context.DeadlineExceeded)
+	<-cancelled
+}
+
+func TestPoolRateLimit(t *testing.T) {
+	pool := NewPool(Config{Workers: 4, QueueSize: 10, RateLimits: map[string]float64{"sms": 20}})
+
+	var times []time.Time
+	done := make(chan time.Time, 3)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pool.Submit(Job{Channel: "sms", Run: func(ctx context.Context) {
+			done <- time.Now()
+		}}))
+	}
+	require.NoError(t, pool.Shutdown(context.Background()))
+	close(done)
+	for at := range done {
+		times = append(times, at)
+	}
+
+	// Three messages at 20 per second take at least 100ms, whatever the
+	// number of workers.
+	require.Len(t, times, 3)
+	first, last := times[0], times[0]
+	for _, at := range times {
+		if at.Before(first) {
+			first = at
+		}
+		if at.After(last) {
+			last = at
+		}
+	}
+	assert.GreaterOrEqual(t, last.Sub(first), 90*time.Millisecond)
+}
+
+func TestPoolRateLimitDoesNotHoldUpOtherChannels(t *testing.T) {
+	pool := NewPool(Config{Workers: 1, QueueSize: 10, RateLimits: map[string]float64{"sms": 1}})
+	defer func() {
+		// Without draining the sms messages still waiting for the limiter.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		pool.Shutdown(ctx)
+	}()
+
+	// The first message goes out at once; the others wait a second each.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pool.Submit(Job{Channel: "sms", Run: func(ctx context.Context) {}}))
+	}
+	sent := make(chan struct{})
+	require.NoError(t, pool.Submit(Job{Channel: "email", Run: func(ctx context.Context) {
+		close(sent)
+	}}))
+
+	select {
+	case <-sent:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("email waited for the throttled sms channel")
+	}
+}
@@ -0,0 +1,73 @@
+// Package archiver moves clients that finished long ago out of the clients
+// table, keeping the tables the queues are ordered from small while their
+// history stays available for reporting.
+package archiver
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"queue-management-system/queue-management-service/repository"
+)
+
+const (
+	// DefaultRetention is how long finished clients stay in the clients
+	// table.
+	DefaultRetention = 30 * 24 * time.Hour
+	// DefaultInterval is how often the archiver looks for clients past the
+	// retention window.
+	DefaultInterval = time.Hour
+)
+
+// batchSize is the number of clients moved per statement, bounding how long
+// rows stay locked.
+const batchSize = 500
+
+// Archiver periodically archives clients that finished more than Retention
+// ago.
+type Archiver struct {
+	clients   repository.ClientRepository
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+func New(clients repository.ClientRepository) *Archiver {
+	return &Archiver{clients: clients, Retention: DefaultRetention, Interval: DefaultInterval}
+}
+
+// Run archives due clients every Interval until ctx is cancelled.
+func (a *Archiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := a.ArchiveDue(ctx)
+		if err != nil {
+			log.Printf("archiver: %v", err)
+		}
+		if n > 0 {
+			log.Printf("archiver: archived %d clients", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ArchiveDue archives every client that finished more than Retention ago and
+// returns how many it archived.
+func (a *Archiver) ArchiveDue(ctx context.Context) (int64, error) {
+	cutoff := time.Now().Add(-a.Retention)
+	var total int64
+	for {
+		n, err := a.clients.Archive(ctx, cutoff, batchSize)
+		total += n
+		if err != nil || n < batchSize {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package archiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"queue-management-system/queue-management-service/models"
+	"queue-management-system/queue-management-service/repository"
+)
+
+func TestArchiveDue(t *testing.T) {
+	ctx := context.Background()
+	queues := repository.NewMemoryQueueRepository()
+	clients := repository.NewMemoryClientRepository(queues)
+	queue := &models.Queue{Name: "Cash desk", OrderingPolicy: models.OrderStrict}
+	require.NoError(t, queues.Create(ctx, queue))
+
+	long := time.Now().Add(-40 * 24 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	add := func(name string, status models.ClientStatus, finished *time.Time) *models.Client {
+		client := &models.Client{QueueID: queue.ID, Name: name, Status: status, JoinedAt: long}
+		switch status {
+		case models.StatusServed:
+			client.ServedAt = finished
+		case models.StatusNoShow:
+			client.NoShowAt = finished
+		case models.StatusCancelled:
+			client.CancelledAt = finished
+		}
+		require.NoError(t, clients.Create(ctx, client))
+		return client
+	}
+	served := add("served long ago", models.StatusServed, &long)
+	noShow := add("no show long ago", models.StatusNoShow, &long)
+	cancelled := add("cancelled recently", models.StatusCancelled, &recent)
+	waiting := add("waiting for ages", models.StatusWaiting, nil)
+
+	a := New(clients)
+	n, err := a.ArchiveDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	for _, archived := range []*models.Client{served, noShow} {
+		_, err := clients.Get(ctx, archived.ID)
+		assert.Equal(t, repository.ErrNotFound, err, archived.Name)
+	}
+	for _, kept := range []*models.Client{cancelled, waiting} {
+		_, err := clients.Get(ctx, kept.ID)
+		assert.NoError(t, err, kept.Name)
+	}
+
+	n, err = a.ArchiveDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
@@ -7,6 +7,8 @@ require (
 	github.com/stretchr/testify v1.9.0
 	google.golang.org/grpc v1.64.0
 	google.golang.org/protobuf v1.33.0
+	notification-service v0.0.0
+	queue-management-system v0.0.0
 )
 
 require (
@@ -18,3 +20,8 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace (
+	notification-service => ../notification-service
+	queue-management-system => ../
+)
@@ -1,22 +1,33 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"time"
 
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	notificationpb "notification-service/pb"
+	"queue-management-system/migrate"
+	"queue-management-system/queue-management-service/archiver"
+	"queue-management-system/queue-management-service/migrations"
+	"queue-management-system/queue-management-service/notifier"
+	"queue-management-system/queue-management-service/repository"
+	"queue-management-system/queue-management-service/scheduler"
 	"queue-management-system/queue-management-service/server"
 
 	pb "queue-management-system/queue-management-service/pb"
 )
 
 func main() {
-	lis, err := net.Listen("tcp", ":50051")
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
 	}
 
 	dsn := os.Getenv("DATABASE_URL")
@@ -28,11 +39,100 @@ func main() {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 
+	if os.Getenv("MIGRATE_ON_START") == "true" {
+		if err := newMigrator(db).Up(context.Background()); err != nil {
+			log.Fatalf("failed to migrate the database: %v", err)
+		}
+	}
+	if err := migrate.Require(context.Background(), db, "client", migrations.ClientVersion); err != nil {
+		log.Fatalf("database is not ready: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", ":50051")
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	queues := repository.NewPostgresQueueRepository(db)
+	clients := repository.NewPostgresClientRepository(db)
+	counters := repository.NewPostgresCounterRepository(db)
+	var n *notifier.Notifier
+	if addr := os.Getenv("NOTIFICATION_SERVICE_ADDR"); addr != "" {
+		n = newNotifier(db, addr)
+	}
+
+	go newArchiver(clients).Run(context.Background())
+	go scheduler.New(queues, n).Run(context.Background())
+
 	s := grpc.NewServer()
-	pb.RegisterQueueManagementServiceServer(s, server.NewQueueManagementService(db))
+	pb.RegisterQueueManagementServiceServer(s, server.NewQueueManagementService(queues, clients, counters, n))
+	pb.RegisterCounterServiceServer(s, server.NewCounterService(counters))
 
 	log.Println("Queue Management Service is running on port :50051")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
+
+// runMigrate carries out the migrate subcommand against DATABASE_URL.
+func runMigrate(args []string) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		log.Fatal("migrate: DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+	if err := migrate.Run(context.Background(), newMigrator(db), args, os.Stdout); err != nil {
+		log.Fatalf("migrate: %v", err)
+	}
+}
+
+// newMigrator returns the migrator of the embedded queue management
+// migrations, which wait for the client migrations they build on.
+func newMigrator(db *sql.DB) *migrate.Migrator {
+	m, err := migrate.New(db, "queue-management", migrations.FS)
+	if err != nil {
+		log.Fatalf("invalid migrations: %v", err)
+	}
+	m.DependsOn("client", migrations.ClientVersion)
+	return m
+}
+
+// newArchiver returns an archiver keeping finished clients for
+// ARCHIVE_RETENTION, a duration such as "720h", before archiving them.
+func newArchiver(clients repository.ClientRepository) *archiver.Archiver {
+	a := archiver.New(clients)
+	if v := os.Getenv("ARCHIVE_RETENTION"); v != "" {
+		retention, err := time.ParseDuration(v)
+		if err != nil || retention <= 0 {
+			log.Fatalf("invalid ARCHIVE_RETENTION %q", v)
+		}
+		a.Retention = retention
+	}
+	return a
+}
+
+// newNotifier connects to NotificationService at addr and starts delivering
+// the notification outbox. NOTIFY_AHEAD_THRESHOLD overrides how many clients
+// may be ahead of a client when it is told its turn is near.
+func newNotifier(db *sql.DB, addr string) *notifier.Notifier {
+	threshold := int32(notifier.DefaultThreshold)
+	if v := os.Getenv("NOTIFY_AHEAD_THRESHOLD"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("invalid NOTIFY_AHEAD_THRESHOLD %q", v)
+		}
+		threshold = int32(n)
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to connect to notification service: %v", err)
+	}
+	dispatcher := notifier.NewDispatcher(db, notificationpb.NewNotificationServiceClient(conn))
+	go dispatcher.Run(context.Background())
+	return notifier.New(threshold, dispatcher)
+}
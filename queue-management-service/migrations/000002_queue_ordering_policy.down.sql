@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_clients_called;
+DROP FUNCTION IF EXISTS queue_positions(INT);
+
+ALTER TABLE queues
+    DROP CONSTRAINT IF EXISTS queues_aging_seconds_check,
+    DROP CONSTRAINT IF EXISTS queues_ordering_policy_check,
+    DROP COLUMN IF EXISTS aging_seconds,
+    DROP COLUMN IF EXISTS ordering_policy;
@@ -0,0 +1,47 @@
+-- How waiting clients of a queue are ordered:
+--   strict   - higher priority first, FIFO within a tier
+--   weighted - tiers interleaved in proportion to priority + 1
+--   aging    - priority grows by one for every aging_seconds spent waiting
+ALTER TABLE queues
+    ADD COLUMN ordering_policy VARCHAR(20) NOT NULL DEFAULT 'strict',
+    ADD COLUMN aging_seconds INT NOT NULL DEFAULT 600,
+    ADD CONSTRAINT queues_ordering_policy_check CHECK (ordering_policy IN ('strict', 'weighted', 'aging')),
+    ADD CONSTRAINT queues_aging_seconds_check CHECK (aging_seconds > 0);
+
+-- queue_positions returns the 1-based place of every waiting client of a
+-- queue under the queue's ordering policy. Both the dequeue path and position
+-- reporting read from it so they always agree. The weighted policy counts the
+-- tiers of the last 10 calls so tiers keep alternating across dequeues.
+CREATE OR REPLACE FUNCTION queue_positions(p_queue_id INT)
+RETURNS TABLE (client_id INT, place BIGINT) AS $$
+    WITH recent AS (
+        SELECT priority, COUNT(*) AS called
+        FROM (
+            SELECT priority FROM clients
+            WHERE queue_id = p_queue_id AND called_at IS NOT NULL
+            ORDER BY called_at DESC
+            LIMIT 10
+        ) last_calls
+        GROUP BY priority
+    )
+    SELECT w.id, ROW_NUMBER() OVER (ORDER BY
+        CASE WHEN q.ordering_policy = 'weighted'
+             THEN (COALESCE(r.called, 0) + w.tier_rank)::float8 / (w.priority + 1)
+             ELSE 0 END,
+        CASE WHEN q.ordering_policy = 'aging'
+             THEN w.priority + FLOOR(EXTRACT(EPOCH FROM NOW() - w.created_at) / q.aging_seconds)
+             ELSE w.priority END DESC,
+        w.created_at, w.id)
+    FROM (
+        SELECT id, priority, created_at,
+               ROW_NUMBER() OVER (PARTITION BY priority ORDER BY created_at, id) AS tier_rank
+        FROM clients
+        WHERE queue_id = p_queue_id AND status = 'waiting'
+    ) w
+    LEFT JOIN recent r ON r.priority = w.priority
+    CROSS JOIN queues q
+    WHERE q.id = p_queue_id
+$$ LANGUAGE SQL STABLE;
+
+-- Index for reading the most recent calls of a queue
+CREATE INDEX idx_clients_called ON clients (queue_id, called_at DESC) WHERE called_at IS NOT NULL;
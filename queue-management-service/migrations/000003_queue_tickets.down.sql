@@ -0,0 +1,6 @@
+ALTER TABLE queues
+    DROP CONSTRAINT IF EXISTS queues_ticket_reset_check,
+    DROP CONSTRAINT IF EXISTS queues_ticket_padding_check,
+    DROP COLUMN IF EXISTS ticket_reset,
+    DROP COLUMN IF EXISTS ticket_padding,
+    DROP COLUMN IF EXISTS ticket_prefix;
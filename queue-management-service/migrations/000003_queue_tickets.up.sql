@@ -0,0 +1,8 @@
+-- Ticket numbering of a queue, e.g. prefix 'A' with padding 3 gives 'A-042'.
+-- Numbers restart every day with reset 'daily' and keep growing with 'never'.
+ALTER TABLE queues
+    ADD COLUMN ticket_prefix VARCHAR(10) NOT NULL DEFAULT '',
+    ADD COLUMN ticket_padding INT NOT NULL DEFAULT 3,
+    ADD COLUMN ticket_reset VARCHAR(10) NOT NULL DEFAULT 'daily',
+    ADD CONSTRAINT queues_ticket_padding_check CHECK (ticket_padding BETWEEN 1 AND 9),
+    ADD CONSTRAINT queues_ticket_reset_check CHECK (ticket_reset IN ('daily', 'never'));
@@ -0,0 +1,8 @@
+-- Alerts already sent to a client, one row per kind ('near_front', 'called'),
+-- so every client is notified of each at most once.
+CREATE TABLE client_alerts (
+    client_id INT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (client_id, kind)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS client_alerts (
+    client_id INT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (client_id, kind)
+);
+
+DROP TABLE IF EXISTS notification_outbox;
@@ -0,0 +1,23 @@
+-- Notifications are written here in the same transaction as the queue change
+-- that caused them and delivered to NotificationService by a dispatcher.
+-- Rows that keep failing end up with status 'dead' and are no longer retried.
+CREATE TABLE notification_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event VARCHAR(30) NOT NULL,
+    channel VARCHAR(20) NOT NULL DEFAULT 'email',
+    recipient TEXT NOT NULL,
+    message TEXT NOT NULL,
+    dedup_key TEXT UNIQUE,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP,
+    CONSTRAINT notification_outbox_status_check CHECK (status IN ('pending', 'sent', 'dead'))
+);
+
+CREATE INDEX idx_notification_outbox_pending ON notification_outbox (next_attempt_at) WHERE status = 'pending';
+
+-- The outbox dedup_key now records which alerts a client has received.
+DROP TABLE IF EXISTS client_alerts;
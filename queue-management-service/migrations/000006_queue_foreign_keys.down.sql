@@ -0,0 +1,2 @@
+ALTER TABLE ticket_sequences DROP CONSTRAINT IF EXISTS ticket_sequences_queue_id_fkey;
+ALTER TABLE clients DROP CONSTRAINT IF EXISTS clients_queue_id_fkey;
@@ -0,0 +1,32 @@
+-- Clients and their ticket sequences belong to a queue. Deleting a queue
+-- takes the clients it has finished with along; DeleteQueue refuses while
+-- clients still wait, are called or are being served.
+--
+-- Before the keys existed queues could be deleted under their clients. Rows
+-- pointing at missing queues are client history, so rather than dropping them
+-- the migration stops and leaves it to the operator to restore their queues or
+-- move the rows elsewhere.
+DO $$
+DECLARE
+    orphaned_clients bigint;
+    orphaned_sequences bigint;
+BEGIN
+    SELECT COUNT(*) INTO orphaned_clients FROM clients c
+        WHERE NOT EXISTS (SELECT 1 FROM queues q WHERE q.id = c.queue_id);
+    SELECT COUNT(*) INTO orphaned_sequences FROM ticket_sequences s
+        WHERE NOT EXISTS (SELECT 1 FROM queues q WHERE q.id = s.queue_id);
+    IF orphaned_clients > 0 OR orphaned_sequences > 0 THEN
+        RAISE EXCEPTION '% clients and % ticket sequences belong to queues that no longer exist',
+            orphaned_clients, orphaned_sequences
+            USING HINT = 'Restore their queues, or move the rows out of clients and ticket_sequences, '
+                'and run the migration again. Find them with: SELECT * FROM clients c '
+                'WHERE NOT EXISTS (SELECT 1 FROM queues q WHERE q.id = c.queue_id)';
+    END IF;
+END;
+$$;
+
+ALTER TABLE clients
+    ADD CONSTRAINT clients_queue_id_fkey FOREIGN KEY (queue_id) REFERENCES queues (id) ON DELETE CASCADE;
+
+ALTER TABLE ticket_sequences
+    ADD CONSTRAINT ticket_sequences_queue_id_fkey FOREIGN KEY (queue_id) REFERENCES queues (id) ON DELETE CASCADE;
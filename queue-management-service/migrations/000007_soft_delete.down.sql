@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS clients_archive;
+
+ALTER TABLE queues DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,26 @@
+-- Deleting a queue only stamps deleted_at so its history stays available for
+-- reporting. Normal reads skip deleted queues.
+ALTER TABLE queues ADD COLUMN deleted_at TIMESTAMP;
+
+-- Finished clients past the retention window are moved here by the archiver,
+-- keeping the clients table, and the queries ordering it, small. Columns
+-- added to clients later have to be added here too.
+CREATE TABLE clients_archive (
+    id INT PRIMARY KEY,
+    queue_id INT NOT NULL REFERENCES queues (id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    ticket_number VARCHAR(20) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP,
+    called_at TIMESTAMP,
+    serving_started_at TIMESTAMP,
+    served_at TIMESTAMP,
+    no_show_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    counter_id INT,
+    archived_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_clients_archive_queue ON clients_archive (queue_id, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE queues DROP COLUMN IF EXISTS schedule_closed;
+ALTER TABLE queues DROP COLUMN IF EXISTS schedule;
@@ -0,0 +1,7 @@
+-- Opening hours are kept as JSON in the shape of schedule.Schedule. Queues
+-- without a schedule are always open.
+ALTER TABLE queues ADD COLUMN schedule JSONB;
+
+-- Set by the scheduler while a scheduled queue is closed, so closing it, and
+-- telling its waiting clients, happens once per closing time.
+ALTER TABLE queues ADD COLUMN schedule_closed BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+ALTER TABLE queues
+    DROP CONSTRAINT IF EXISTS queues_overflow_policy_check,
+    DROP CONSTRAINT IF EXISTS queues_max_size_check,
+    DROP COLUMN IF EXISTS overflow_queue_id,
+    DROP COLUMN IF EXISTS overflow_policy,
+    DROP COLUMN IF EXISTS max_size;
@@ -0,0 +1,9 @@
+-- max_size caps the waiting clients of a queue, 0 for no cap. Clients
+-- registering in a full queue are turned away, registered in
+-- overflow_queue_id or waitlisted, as overflow_policy says.
+ALTER TABLE queues
+    ADD COLUMN max_size INT NOT NULL DEFAULT 0,
+    ADD COLUMN overflow_policy VARCHAR(20) NOT NULL DEFAULT 'reject',
+    ADD COLUMN overflow_queue_id INT REFERENCES queues (id) ON DELETE SET NULL,
+    ADD CONSTRAINT queues_max_size_check CHECK (max_size >= 0),
+    ADD CONSTRAINT queues_overflow_policy_check CHECK (overflow_policy IN ('reject', 'redirect', 'waitlist'));
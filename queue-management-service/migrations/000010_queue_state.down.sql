@@ -0,0 +1,3 @@
+ALTER TABLE queues
+    DROP CONSTRAINT IF EXISTS queues_state_check,
+    DROP COLUMN IF EXISTS state;
@@ -0,0 +1,5 @@
+-- state is set by operators: paused queues take clients but call none,
+-- closed and draining ones call the clients they have but take no new ones.
+ALTER TABLE queues
+    ADD COLUMN state VARCHAR(20) NOT NULL DEFAULT 'open',
+    ADD CONSTRAINT queues_state_check CHECK (state IN ('open', 'paused', 'closed', 'draining'));
@@ -0,0 +1,4 @@
+ALTER TABLE clients DROP CONSTRAINT IF EXISTS clients_counter_id_fkey;
+
+DROP TABLE IF EXISTS counter_queues;
+DROP TABLE IF EXISTS counters;
@@ -0,0 +1,33 @@
+-- Counters (desks) call the clients of the queues they are assigned to while
+-- they are open. Clients keep the counter that called them in counter_id.
+CREATE TABLE counters (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    open BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE counter_queues (
+    counter_id INT NOT NULL REFERENCES counters (id) ON DELETE CASCADE,
+    queue_id INT NOT NULL REFERENCES queues (id) ON DELETE CASCADE,
+    PRIMARY KEY (counter_id, queue_id)
+);
+
+CREATE INDEX idx_counter_queues_queue ON counter_queues (queue_id);
+
+-- Counters that called clients before counters were stored are created
+-- closed, and assigned to the queues they called from.
+INSERT INTO counters (id, name)
+SELECT counter_id, 'Counter ' || counter_id
+FROM (SELECT counter_id FROM clients UNION SELECT counter_id FROM clients_archive) called
+WHERE counter_id IS NOT NULL;
+
+SELECT setval(pg_get_serial_sequence('counters', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM counters;
+
+INSERT INTO counter_queues (counter_id, queue_id)
+SELECT counter_id, queue_id
+FROM (SELECT counter_id, queue_id FROM clients UNION SELECT counter_id, queue_id FROM clients_archive) called
+WHERE counter_id IS NOT NULL;
+
+ALTER TABLE clients
+    ADD CONSTRAINT clients_counter_id_fkey FOREIGN KEY (counter_id) REFERENCES counters (id) ON DELETE SET NULL;
@@ -0,0 +1 @@
+COMMENT ON FUNCTION queue_positions(INT) IS NULL;
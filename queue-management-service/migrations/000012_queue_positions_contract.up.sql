@@ -0,0 +1,9 @@
+-- queue_positions is shared with the client service, which reports places
+-- from it while this service dequeues by it. These migrations own it: it may
+-- change how clients are ordered, but must keep taking a queue ID and
+-- returning (client_id INT, place BIGINT), places 1-based and only for
+-- waiting clients. A change the client service has to follow goes with a
+-- bump of its migrations.QueueManagementVersion.
+COMMENT ON FUNCTION queue_positions(INT) IS
+    'Shared with the client service and owned by the queue-management migrations: '
+    'returns (client_id, place) for the waiting clients of a queue, places 1-based.';
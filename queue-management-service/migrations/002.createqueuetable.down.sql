@@ -1 +0,0 @@
-DELETE TABLE IF NOT EXISTS queues;
\ No newline at end of file
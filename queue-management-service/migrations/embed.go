@@ -0,0 +1,20 @@
+// Package migrations embeds the SQL migrations of the service so the binary
+// can apply them itself. They complete the schema the client service
+// migrations start, adding queues, the keys tying clients to them and the
+// counters calling them, so they are only applied once the client
+// migrations are applied up to ClientVersion.
+//
+// The dependency runs the other way as well: the client service reads the
+// queue columns, the queue_positions function and the counters created here,
+// and refuses to serve until these migrations are applied up to the version
+// it names.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
+
+// ClientVersion is the version of the client service migrations these
+// migrations, and the service reading the clients, build on.
+const ClientVersion = 9
@@ -1,12 +1,264 @@
 package models
 
+import (
+	"time"
+
+	"queue-management-system/schedule"
+)
+
 type Queue struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID             int32          `json:"id"`
+	Name           string         `json:"name"`
+	OrderingPolicy OrderingPolicy `json:"ordering_policy"`
+	AgingSeconds   int32          `json:"aging_seconds"`
+	TicketPrefix   string         `json:"ticket_prefix"`
+	TicketPadding  int32          `json:"ticket_padding"`
+	TicketReset    TicketReset    `json:"ticket_reset"`
+	// DeletedAt is set once the queue is deleted. Deleted queues are kept,
+	// with their clients, for reporting.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Schedule is when the queue takes new clients. Queues without one are
+	// always open.
+	Schedule *schedule.Schedule `json:"schedule,omitempty"`
+	// ScheduleClosed is set by the scheduler while the schedule keeps the
+	// queue closed.
+	ScheduleClosed bool `json:"schedule_closed"`
+	// MaxSize caps the waiting clients, zero for no cap. OverflowPolicy
+	// decides what happens to clients registering while the queue is full.
+	MaxSize        int32          `json:"max_size"`
+	OverflowPolicy OverflowPolicy `json:"overflow_policy"`
+	// OverflowQueueID is where OverflowRedirect sends clients.
+	OverflowQueueID int32 `json:"overflow_queue_id,omitempty"`
+	// State is set by operators to pause, close or drain the queue.
+	State QueueState `json:"state"`
+}
+
+// IsOpen reports whether the queue's schedule lets clients join at t.
+func (q *Queue) IsOpen(t time.Time) bool {
+	return q.Schedule == nil || q.Schedule.IsOpen(t)
+}
+
+// QueueStatus is the state of a queue as reported to operators.
+type QueueStatus string
+
+const (
+	QueueActive  QueueStatus = "active"
+	QueueDeleted QueueStatus = "deleted"
+)
+
+// Status returns the state the queue is in.
+func (q *Queue) Status() QueueStatus {
+	if q.DeletedAt != nil {
+		return QueueDeleted
+	}
+	return QueueActive
+}
+
+// Valid reports whether s is a known queue status.
+func (s QueueStatus) Valid() bool {
+	return s == QueueActive || s == QueueDeleted
+}
+
+// QueueState is whether a queue takes new clients and calls the ones it has.
+// It is set by operators, independently of the queue's schedule.
+type QueueState string
+
+const (
+	// QueueOpen takes and calls clients.
+	QueueOpen QueueState = "open"
+	// QueuePaused takes clients but calls none.
+	QueuePaused QueueState = "paused"
+	// QueueClosed takes no new clients and keeps calling the ones it has.
+	QueueClosed QueueState = "closed"
+	// QueueDraining is closed, and its waiting clients have been told so.
+	QueueDraining QueueState = "draining"
+)
+
+// AcceptsClients reports whether clients can register in a queue in state s.
+func (s QueueState) AcceptsClients() bool {
+	return s != QueueClosed && s != QueueDraining
+}
+
+// CallsClients reports whether counters can call the clients of a queue in
+// state s.
+func (s QueueState) CallsClients() bool {
+	return s != QueuePaused
+}
+
+// OrderingPolicy decides how waiting clients of different priority tiers are
+// ordered within a queue.
+type OrderingPolicy string
+
+const (
+	// OrderStrict serves higher tiers first and is FIFO within a tier.
+	OrderStrict OrderingPolicy = "strict"
+	// OrderWeighted interleaves tiers in proportion to priority + 1.
+	OrderWeighted OrderingPolicy = "weighted"
+	// OrderAging promotes clients by one tier for every AgingSeconds waited.
+	OrderAging OrderingPolicy = "aging"
+)
+
+// DefaultAgingSeconds is used when a queue is created without an aging interval.
+const DefaultAgingSeconds = 600
+
+// Valid reports whether p is a known ordering policy.
+func (p OrderingPolicy) Valid() bool {
+	switch p {
+	case OrderStrict, OrderWeighted, OrderAging:
+		return true
+	}
+	return false
+}
+
+// OverflowPolicy is what a full queue does with clients registering in it.
+type OverflowPolicy string
+
+const (
+	// OverflowReject turns clients away.
+	OverflowReject OverflowPolicy = "reject"
+	// OverflowRedirect registers clients in the queue's overflow queue.
+	OverflowRedirect OverflowPolicy = "redirect"
+	// OverflowWaitlist registers clients as waitlisted. They become waiting,
+	// in the order they registered, as places free up.
+	OverflowWaitlist OverflowPolicy = "waitlist"
+)
+
+// Valid reports whether p is a known overflow policy.
+func (p OverflowPolicy) Valid() bool {
+	switch p {
+	case OverflowReject, OverflowRedirect, OverflowWaitlist:
+		return true
+	}
+	return false
+}
+
+// TicketReset is how often the ticket numbers of a queue start over.
+type TicketReset string
+
+const (
+	TicketResetDaily TicketReset = "daily"
+	TicketResetNever TicketReset = "never"
+)
+
+// Valid reports whether r is a known reset schedule.
+func (r TicketReset) Valid() bool {
+	return r == TicketResetDaily || r == TicketResetNever
+}
+
+const (
+	// DefaultTicketPadding is used when a queue is created without a padding.
+	DefaultTicketPadding = 3
+	// MaxTicketPadding and MaxTicketPrefixLength bound the printed ticket.
+	MaxTicketPadding      = 9
+	MaxTicketPrefixLength = 10
+)
+
+// ClientStatus is the lifecycle state of a client within a queue.
+type ClientStatus string
+
+const (
+	// StatusWaitlisted clients registered while their queue was full. They
+	// are not in line yet; the repositories promote them to waiting.
+	StatusWaitlisted ClientStatus = "waitlisted"
+	StatusWaiting    ClientStatus = "waiting"
+	StatusCalled     ClientStatus = "called"
+	StatusServing    ClientStatus = "serving"
+	StatusServed     ClientStatus = "served"
+	StatusNoShow     ClientStatus = "no_show"
+	StatusCancelled  ClientStatus = "cancelled"
+)
+
+// clientTransitions lists the states each state may move to. States without
+// an entry are terminal.
+var clientTransitions = map[ClientStatus][]ClientStatus{
+	StatusWaitlisted: {StatusCancelled},
+	StatusWaiting:    {StatusCalled, StatusCancelled},
+	StatusCalled:     {StatusServing, StatusNoShow, StatusCancelled},
+	StatusServing:    {StatusServed},
+}
+
+// ActiveStatuses are the states in which a client still occupies the queue.
+var ActiveStatuses = []ClientStatus{StatusWaiting, StatusCalled, StatusServing}
+
+// FinishedStatuses are the terminal states.
+var FinishedStatuses = []ClientStatus{StatusServed, StatusNoShow, StatusCancelled}
+
+// IsFinished reports whether s is a terminal state.
+func (s ClientStatus) IsFinished() bool {
+	for _, finished := range FinishedStatuses {
+		if s == finished {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive reports whether a client in this state still occupies the queue.
+func (s ClientStatus) IsActive() bool {
+	for _, active := range ActiveStatuses {
+		if s == active {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a client may move from s to next.
+func (s ClientStatus) CanTransitionTo(next ClientStatus) bool {
+	for _, allowed := range clientTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// PreviousStatuses returns the states from which a client may move to next.
+func PreviousStatuses(next ClientStatus) []ClientStatus {
+	var previous []ClientStatus
+	for from, targets := range clientTransitions {
+		for _, to := range targets {
+			if to == next {
+				previous = append(previous, from)
+			}
+		}
+	}
+	return previous
 }
 
 type Client struct {
-	ID      int32  `json:"id"`
-	QueueID int32  `json:"queue_id"`
-	Name    string `json:"name"`
+	ID               int32        `json:"id"`
+	QueueID          int32        `json:"queue_id"`
+	Name             string       `json:"name"`
+	Email            string       `json:"email"`
+	TicketNumber     string       `json:"ticket_number"`
+	Status           ClientStatus `json:"status"`
+	Priority         int32        `json:"priority"`
+	JoinedAt         time.Time    `json:"joined_at"`
+	CalledAt         *time.Time   `json:"called_at,omitempty"`
+	ServingStartedAt *time.Time   `json:"serving_started_at,omitempty"`
+	ServedAt         *time.Time   `json:"served_at,omitempty"`
+	NoShowAt         *time.Time   `json:"no_show_at,omitempty"`
+	CancelledAt      *time.Time   `json:"cancelled_at,omitempty"`
+	CounterID        int32        `json:"counter_id,omitempty"`
+}
+
+// Counter is a desk calling the clients of the queues it is assigned to
+// while it is open.
+type Counter struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	Open      bool      `json:"open"`
+	QueueIDs  []int32   `json:"queue_ids"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Serves reports whether the counter is assigned to a queue.
+func (c *Counter) Serves(queueID int32) bool {
+	for _, id := range c.QueueIDs {
+		if id == queueID {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,196 @@
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	notificationpb "notification-service/pb"
+)
+
+const (
+	// DefaultMaxAttempts is how often a message is tried before it is
+	// dead-lettered.
+	DefaultMaxAttempts = 8
+	// DefaultBaseDelay is the wait before the first retry; every further
+	// retry waits twice as long, up to DefaultMaxDelay.
+	DefaultBaseDelay = 5 * time.Second
+	DefaultMaxDelay  = 30 * time.Minute
+	// DefaultPollInterval is how often the outbox is checked for messages
+	// that are due when the dispatcher is not woken up.
+	DefaultPollInterval = 5 * time.Second
+)
+
+const (
+	// batchSize is the number of messages claimed at once.
+	batchSize = 20
+	// sendTimeout bounds a single SendNotification call.
+	sendTimeout = 10 * time.Second
+	// claimLease is how long claimed messages are hidden from other
+	// dispatchers. Messages of a dispatcher that dies mid-batch become due
+	// again once it expires.
+	claimLease = time.Minute
+)
+
+// Dispatcher delivers outbox messages to NotificationService. Several
+// dispatchers may share an outbox; every message is claimed by one of them.
+type Dispatcher struct {
+	db           *sql.DB
+	client       notificationpb.NotificationServiceClient
+	MaxAttempts  int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	PollInterval time.Duration
+	wake         chan struct{}
+}
+
+func NewDispatcher(db *sql.DB, client notificationpb.NotificationServiceClient) *Dispatcher {
+	return &Dispatcher{
+		db:           db,
+		client:       client,
+		MaxAttempts:  DefaultMaxAttempts,
+		BaseDelay:    DefaultBaseDelay,
+		MaxDelay:     DefaultMaxDelay,
+		PollInterval: DefaultPollInterval,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Wake makes Run check the outbox right away. It never blocks.
+func (d *Dispatcher) Wake() {
+	if d == nil {
+		return
+	}
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers due messages until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while full batches come back, there may be more due.
+		for {
+			n, err := d.DispatchBatch(ctx)
+			if err != nil {
+				log.Printf("outbox: %v", err)
+			}
+			if err != nil || n < batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+type outboxMessage struct {
+	id        int64
+	channel   string
+	recipient string
+	body      string
+	attempts  int
+}
+
+// DispatchBatch claims a batch of due messages and tries to deliver each of
+// them once. It returns the number of messages claimed.
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		UPDATE notification_outbox SET next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM notification_outbox
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, channel, recipient, message, attempts`, batchSize, claimLease.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	var batch []outboxMessage
+	for rows.Next() {
+		var m outboxMessage
+		if err := rows.Scan(&m.id, &m.channel, &m.recipient, &m.body, &m.attempts); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, m := range batch {
+		d.deliver(ctx, m)
+	}
+	return len(batch), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, m outboxMessage) {
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	_, sendErr := d.client.SendNotification(sendCtx, &notificationpb.SendNotificationRequest{
+		Message: m.body,
+		Channel: m.channel,
+		Email:   m.recipient,
+	})
+	cancel()
+
+	attempts := m.attempts + 1
+	var err error
+	switch {
+	case sendErr == nil:
+		_, err = d.db.ExecContext(ctx, `
+			UPDATE notification_outbox SET status = 'sent', attempts = $2, sent_at = NOW(), last_error = NULL
+			WHERE id = $1`, m.id, attempts)
+	case attempts >= d.MaxAttempts || !retryable(sendErr):
+		log.Printf("outbox: giving up on message %d after %d attempts: %v", m.id, attempts, sendErr)
+		_, err = d.db.ExecContext(ctx, `
+			UPDATE notification_outbox SET status = 'dead', attempts = $2, last_error = $3
+			WHERE id = $1`, m.id, attempts, sendErr.Error())
+	default:
+		_, err = d.db.ExecContext(ctx, `
+			UPDATE notification_outbox SET attempts = $2, last_error = $3,
+				next_attempt_at = NOW() + $4 * INTERVAL '1 second'
+			WHERE id = $1`, m.id, attempts, sendErr.Error(), d.backoff(attempts).Seconds())
+	}
+	if err != nil {
+		log.Printf("outbox: failed to record delivery of message %d: %v", m.id, err)
+	}
+}
+
+// backoff returns the wait before retrying a message that failed attempts
+// times.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.BaseDelay
+	for i := 1; i < attempts && delay < d.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > d.MaxDelay {
+		delay = d.MaxDelay
+	}
+	return delay
+}
+
+// retryable reports whether a failed send may succeed when tried again.
+// Requests NotificationService rejects as invalid never will.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.Unimplemented:
+		return false
+	}
+	return true
+}
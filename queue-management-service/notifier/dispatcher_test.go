@@ -0,0 +1,28 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBackoff(t *testing.T) {
+	d := &Dispatcher{BaseDelay: 5 * time.Second, MaxDelay: time.Minute}
+
+	assert.Equal(t, 5*time.Second, d.backoff(1))
+	assert.Equal(t, 10*time.Second, d.backoff(2))
+	assert.Equal(t, 40*time.Second, d.backoff(4))
+	assert.Equal(t, time.Minute, d.backoff(5))
+	assert.Equal(t, time.Minute, d.backoff(50))
+}
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, retryable(status.Error(codes.Unavailable, "connection refused")))
+	assert.True(t, retryable(status.Error(codes.Internal, "failed to send email")))
+	assert.True(t, retryable(errors.New("network error")))
+	assert.False(t, retryable(status.Error(codes.InvalidArgument, "Email is required for email notifications")))
+}
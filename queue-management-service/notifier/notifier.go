@@ -0,0 +1,230 @@
+// Package notifier alerts clients by email as they progress through a queue:
+// once when they come within a few places of the front and once when they
+// are called to a counter.
+//
+// Alerts are written to the notification_outbox table in the transaction
+// that changes the queue, and delivered by a Dispatcher afterwards, so an
+// alert is sent if and only if the change that caused it was committed.
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"queue-management-system/queue-management-service/models"
+)
+
+// Alert is a kind of notification. A client receives each kind at most once,
+// except AlertQueueClosed, which it receives once for every closing.
+type Alert string
+
+const (
+	AlertNearFront   Alert = "near_front"
+	AlertCalled      Alert = "called"
+	AlertQueueClosed Alert = "queue_closed"
+)
+
+// Closing is why a queue closed for new clients.
+type Closing string
+
+const (
+	ClosingSchedule Closing = "schedule"
+	ClosingDrain    Closing = "drain"
+)
+
+// DefaultThreshold is how many clients may be ahead of a client for it to be
+// alerted that its turn is near.
+const DefaultThreshold = 3
+
+// Querier is satisfied by both *sql.DB and *sql.Tx. Alerts are enqueued
+// through the transaction of the change that triggers them.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Message is a notification waiting in the outbox.
+type Message struct {
+	Event     string
+	Channel   string
+	Recipient string
+	Body      string
+	// DedupKey, when set, makes enqueueing a second message with the same
+	// key a no-op.
+	DedupKey string
+}
+
+// Notifier decides which alerts queue changes cause and enqueues them. A nil
+// Notifier enqueues nothing.
+type Notifier struct {
+	threshold  int32
+	dispatcher *Dispatcher
+}
+
+// New returns a Notifier alerting clients with at most threshold clients
+// ahead of them. Enqueued messages are delivered by d.
+func New(threshold int32, d *Dispatcher) *Notifier {
+	return &Notifier{threshold: threshold, dispatcher: d}
+}
+
+// Wake tells the dispatcher that new messages were committed, so they are
+// delivered without waiting for its next poll.
+func (n *Notifier) Wake() {
+	if n == nil {
+		return
+	}
+	n.dispatcher.Wake()
+}
+
+// ClientCalled enqueues the alert telling a client it was called to a
+// counter, followed by alerts for the clients that moved up behind it.
+func (n *Notifier) ClientCalled(ctx context.Context, q Querier, client *models.Client) error {
+	if n == nil {
+		return nil
+	}
+	if client.Email != "" {
+		err := n.Enqueue(ctx, q, Message{
+			Event:     string(AlertCalled),
+			Channel:   "email",
+			Recipient: client.Email,
+			Body: fmt.Sprintf("Hello %s, ticket %s is being called. Please proceed to counter %d.",
+				client.Name, client.TicketNumber, client.CounterID),
+			DedupKey: dedupKey(AlertCalled, client.ID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return n.QueueMoved(ctx, q, client.QueueID)
+}
+
+// QueueMoved enqueues alerts for the waiting clients of a queue that are now
+// within the threshold of the front and have not been alerted yet.
+func (n *Notifier) QueueMoved(ctx context.Context, q Querier, queueID int32) error {
+	if n == nil {
+		return nil
+	}
+	rows, err := q.QueryContext(ctx, `
+		SELECT c.id, c.name, c.email, c.ticket_number, p.place FROM clients c
+		JOIN queue_positions($1) p ON p.client_id = c.id
+		WHERE p.place <= $2 + 1 AND c.email <> ''
+		ORDER BY p.place`, queueID, n.threshold)
+	if err != nil {
+		return err
+	}
+
+	var due []Message
+	for rows.Next() {
+		var client models.Client
+		var place int32
+		if err := rows.Scan(&client.ID, &client.Name, &client.Email, &client.TicketNumber, &place); err != nil {
+			rows.Close()
+			return err
+		}
+		body := fmt.Sprintf("Hello %s, your turn is coming up: %d clients are ahead of ticket %s.",
+			client.Name, place-1, client.TicketNumber)
+		if place == 1 {
+			body = fmt.Sprintf("Hello %s, ticket %s is next in line.", client.Name, client.TicketNumber)
+		}
+		due = append(due, Message{
+			Event:     string(AlertNearFront),
+			Channel:   "email",
+			Recipient: client.Email,
+			Body:      body,
+			DedupKey:  dedupKey(AlertNearFront, client.ID),
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, m := range due {
+		if err := n.Enqueue(ctx, q, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QueueClosed enqueues a notice for the clients waiting in a queue that it
+// closed for new clients. Clients already waiting keep their place. A client
+// is told once per reason and day, so one still waiting when the queue closes
+// again is told again.
+func (n *Notifier) QueueClosed(ctx context.Context, q Querier, queue *models.Queue, closing Closing) error {
+	if n == nil {
+		return nil
+	}
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, name, email, ticket_number FROM clients
+		WHERE queue_id = $1 AND status = 'waiting' AND email <> ''`, queue.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var due []Message
+	for rows.Next() {
+		var client models.Client
+		if err := rows.Scan(&client.ID, &client.Name, &client.Email, &client.TicketNumber); err != nil {
+			rows.Close()
+			return err
+		}
+		due = append(due, Message{
+			Event:     string(AlertQueueClosed),
+			Channel:   "email",
+			Recipient: client.Email,
+			Body: fmt.Sprintf("Hello %s, %s has closed for new clients. Ticket %s keeps its place in line.",
+				client.Name, queue.Name, client.TicketNumber),
+			DedupKey: closedDedupKey(queue, closing, now, client.ID),
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, m := range due {
+		if err := n.Enqueue(ctx, q, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Enqueue adds a message to the outbox. Messages whose dedup key is already
+// in the outbox are skipped.
+func (n *Notifier) Enqueue(ctx context.Context, q Querier, m Message) error {
+	if n == nil {
+		return nil
+	}
+	var dedup interface{}
+	if m.DedupKey != "" {
+		dedup = m.DedupKey
+	}
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO notification_outbox (event, channel, recipient, message, dedup_key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (dedup_key) DO NOTHING`,
+		m.Event, m.Channel, m.Recipient, m.Body, dedup)
+	return err
+}
+
+func dedupKey(alert Alert, clientID int32) string {
+	return fmt.Sprintf("%s:%d", alert, clientID)
+}
+
+// closedDedupKey keys a closing notice by the reason and the day, in the time
+// zone of the queue's schedule, that the queue closed at.
+func closedDedupKey(queue *models.Queue, closing Closing, at time.Time, clientID int32) string {
+	loc := time.UTC
+	if queue.Schedule != nil {
+		if zone, err := time.LoadLocation(queue.Schedule.TimeZone); err == nil {
+			loc = zone
+		}
+	}
+	return fmt.Sprintf("%s:%s:%s:%d", AlertQueueClosed, closing, at.In(loc).Format("2006-01-02"), clientID)
+}
@@ -0,0 +1,25 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"queue-management-system/queue-management-service/models"
+	"queue-management-system/schedule"
+)
+
+func TestClosedDedupKey(t *testing.T) {
+	queue := &models.Queue{ID: 1, Schedule: &schedule.Schedule{TimeZone: "Asia/Almaty"}}
+	evening := time.Date(2024, 5, 6, 18, 0, 0, 0, time.UTC)
+
+	key := closedDedupKey(queue, ClosingSchedule, evening, 7)
+	assert.Equal(t, "queue_closed:schedule:2024-05-06:7", key)
+	assert.Equal(t, "queue_closed:schedule:2024-05-07:7", closedDedupKey(queue, ClosingSchedule, evening.Add(6*time.Hour), 7),
+		"the day is taken in the time zone of the schedule")
+	assert.NotEqual(t, key, closedDedupKey(queue, ClosingSchedule, evening.AddDate(0, 0, 1), 7),
+		"a client still waiting at the next closing is told again")
+	assert.NotEqual(t, key, closedDedupKey(queue, ClosingDrain, evening, 7),
+		"a client told of the scheduled closing is still told of a drain")
+	assert.Equal(t, "queue_closed:drain:2024-05-06:7", closedDedupKey(&models.Queue{ID: 1}, ClosingDrain, evening, 7))
+}
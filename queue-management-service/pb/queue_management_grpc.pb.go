@@ -19,10 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	QueueManagementService_CreateQueue_FullMethodName    = "/queue.QueueManagementService/CreateQueue"
-	QueueManagementService_UpdateQueue_FullMethodName    = "/queue.QueueManagementService/UpdateQueue"
-	QueueManagementService_DeleteQueue_FullMethodName    = "/queue.QueueManagementService/DeleteQueue"
-	QueueManagementService_GetQueueStatus_FullMethodName = "/queue.QueueManagementService/GetQueueStatus"
+	QueueManagementService_CreateQueue_FullMethodName     = "/queue.QueueManagementService/CreateQueue"
+	QueueManagementService_UpdateQueue_FullMethodName     = "/queue.QueueManagementService/UpdateQueue"
+	QueueManagementService_DeleteQueue_FullMethodName     = "/queue.QueueManagementService/DeleteQueue"
+	QueueManagementService_RestoreQueue_FullMethodName    = "/queue.QueueManagementService/RestoreQueue"
+	QueueManagementService_PauseQueue_FullMethodName      = "/queue.QueueManagementService/PauseQueue"
+	QueueManagementService_CloseQueue_FullMethodName      = "/queue.QueueManagementService/CloseQueue"
+	QueueManagementService_DrainQueue_FullMethodName      = "/queue.QueueManagementService/DrainQueue"
+	QueueManagementService_ReopenQueue_FullMethodName     = "/queue.QueueManagementService/ReopenQueue"
+	QueueManagementService_GetQueueStatus_FullMethodName  = "/queue.QueueManagementService/GetQueueStatus"
+	QueueManagementService_ListQueues_FullMethodName      = "/queue.QueueManagementService/ListQueues"
+	QueueManagementService_CallNext_FullMethodName        = "/queue.QueueManagementService/CallNext"
+	QueueManagementService_StartService_FullMethodName    = "/queue.QueueManagementService/StartService"
+	QueueManagementService_CompleteService_FullMethodName = "/queue.QueueManagementService/CompleteService"
+	QueueManagementService_MarkNoShow_FullMethodName      = "/queue.QueueManagementService/MarkNoShow"
+	QueueManagementService_CancelTicket_FullMethodName    = "/queue.QueueManagementService/CancelTicket"
 )
 
 // QueueManagementServiceClient is the client API for QueueManagementService service.
@@ -32,7 +43,18 @@ type QueueManagementServiceClient interface {
 	CreateQueue(ctx context.Context, in *CreateQueueRequest, opts ...grpc.CallOption) (*CreateQueueResponse, error)
 	UpdateQueue(ctx context.Context, in *UpdateQueueRequest, opts ...grpc.CallOption) (*UpdateQueueResponse, error)
 	DeleteQueue(ctx context.Context, in *DeleteQueueRequest, opts ...grpc.CallOption) (*DeleteQueueResponse, error)
+	RestoreQueue(ctx context.Context, in *RestoreQueueRequest, opts ...grpc.CallOption) (*RestoreQueueResponse, error)
+	PauseQueue(ctx context.Context, in *PauseQueueRequest, opts ...grpc.CallOption) (*PauseQueueResponse, error)
+	CloseQueue(ctx context.Context, in *CloseQueueRequest, opts ...grpc.CallOption) (*CloseQueueResponse, error)
+	DrainQueue(ctx context.Context, in *DrainQueueRequest, opts ...grpc.CallOption) (*DrainQueueResponse, error)
+	ReopenQueue(ctx context.Context, in *ReopenQueueRequest, opts ...grpc.CallOption) (*ReopenQueueResponse, error)
 	GetQueueStatus(ctx context.Context, in *GetQueueStatusRequest, opts ...grpc.CallOption) (*GetQueueStatusResponse, error)
+	ListQueues(ctx context.Context, in *ListQueuesRequest, opts ...grpc.CallOption) (*ListQueuesResponse, error)
+	CallNext(ctx context.Context, in *CallNextRequest, opts ...grpc.CallOption) (*CallNextResponse, error)
+	StartService(ctx context.Context, in *StartServiceRequest, opts ...grpc.CallOption) (*StartServiceResponse, error)
+	CompleteService(ctx context.Context, in *CompleteServiceRequest, opts ...grpc.CallOption) (*CompleteServiceResponse, error)
+	MarkNoShow(ctx context.Context, in *MarkNoShowRequest, opts ...grpc.CallOption) (*MarkNoShowResponse, error)
+	CancelTicket(ctx context.Context, in *CancelTicketRequest, opts ...grpc.CallOption) (*CancelTicketResponse, error)
 }
 
 type queueManagementServiceClient struct {
@@ -73,6 +95,56 @@ func (c *queueManagementServiceClient) DeleteQueue(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *queueManagementServiceClient) RestoreQueue(ctx context.Context, in *RestoreQueueRequest, opts ...grpc.CallOption) (*RestoreQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreQueueResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_RestoreQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) PauseQueue(ctx context.Context, in *PauseQueueRequest, opts ...grpc.CallOption) (*PauseQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseQueueResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_PauseQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) CloseQueue(ctx context.Context, in *CloseQueueRequest, opts ...grpc.CallOption) (*CloseQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseQueueResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_CloseQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) DrainQueue(ctx context.Context, in *DrainQueueRequest, opts ...grpc.CallOption) (*DrainQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DrainQueueResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_DrainQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) ReopenQueue(ctx context.Context, in *ReopenQueueRequest, opts ...grpc.CallOption) (*ReopenQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReopenQueueResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_ReopenQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queueManagementServiceClient) GetQueueStatus(ctx context.Context, in *GetQueueStatusRequest, opts ...grpc.CallOption) (*GetQueueStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetQueueStatusResponse)
@@ -83,6 +155,66 @@ func (c *queueManagementServiceClient) GetQueueStatus(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *queueManagementServiceClient) ListQueues(ctx context.Context, in *ListQueuesRequest, opts ...grpc.CallOption) (*ListQueuesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQueuesResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_ListQueues_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) CallNext(ctx context.Context, in *CallNextRequest, opts ...grpc.CallOption) (*CallNextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CallNextResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_CallNext_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) StartService(ctx context.Context, in *StartServiceRequest, opts ...grpc.CallOption) (*StartServiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartServiceResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_StartService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) CompleteService(ctx context.Context, in *CompleteServiceRequest, opts ...grpc.CallOption) (*CompleteServiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteServiceResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_CompleteService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) MarkNoShow(ctx context.Context, in *MarkNoShowRequest, opts ...grpc.CallOption) (*MarkNoShowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkNoShowResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_MarkNoShow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queueManagementServiceClient) CancelTicket(ctx context.Context, in *CancelTicketRequest, opts ...grpc.CallOption) (*CancelTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTicketResponse)
+	err := c.cc.Invoke(ctx, QueueManagementService_CancelTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueueManagementServiceServer is the server API for QueueManagementService service.
 // All implementations must embed UnimplementedQueueManagementServiceServer
 // for forward compatibility
@@ -90,7 +222,18 @@ type QueueManagementServiceServer interface {
 	CreateQueue(context.Context, *CreateQueueRequest) (*CreateQueueResponse, error)
 	UpdateQueue(context.Context, *UpdateQueueRequest) (*UpdateQueueResponse, error)
 	DeleteQueue(context.Context, *DeleteQueueRequest) (*DeleteQueueResponse, error)
+	RestoreQueue(context.Context, *RestoreQueueRequest) (*RestoreQueueResponse, error)
+	PauseQueue(context.Context, *PauseQueueRequest) (*PauseQueueResponse, error)
+	CloseQueue(context.Context, *CloseQueueRequest) (*CloseQueueResponse, error)
+	DrainQueue(context.Context, *DrainQueueRequest) (*DrainQueueResponse, error)
+	ReopenQueue(context.Context, *ReopenQueueRequest) (*ReopenQueueResponse, error)
 	GetQueueStatus(context.Context, *GetQueueStatusRequest) (*GetQueueStatusResponse, error)
+	ListQueues(context.Context, *ListQueuesRequest) (*ListQueuesResponse, error)
+	CallNext(context.Context, *CallNextRequest) (*CallNextResponse, error)
+	StartService(context.Context, *StartServiceRequest) (*StartServiceResponse, error)
+	CompleteService(context.Context, *CompleteServiceRequest) (*CompleteServiceResponse, error)
+	MarkNoShow(context.Context, *MarkNoShowRequest) (*MarkNoShowResponse, error)
+	CancelTicket(context.Context, *CancelTicketRequest) (*CancelTicketResponse, error)
 	mustEmbedUnimplementedQueueManagementServiceServer()
 }
 
@@ -107,9 +250,42 @@ func (UnimplementedQueueManagementServiceServer) UpdateQueue(context.Context, *U
 func (UnimplementedQueueManagementServiceServer) DeleteQueue(context.Context, *DeleteQueueRequest) (*DeleteQueueResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteQueue not implemented")
 }
+func (UnimplementedQueueManagementServiceServer) RestoreQueue(context.Context, *RestoreQueueRequest) (*RestoreQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreQueue not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) PauseQueue(context.Context, *PauseQueueRequest) (*PauseQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseQueue not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) CloseQueue(context.Context, *CloseQueueRequest) (*CloseQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseQueue not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) DrainQueue(context.Context, *DrainQueueRequest) (*DrainQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainQueue not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) ReopenQueue(context.Context, *ReopenQueueRequest) (*ReopenQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReopenQueue not implemented")
+}
 func (UnimplementedQueueManagementServiceServer) GetQueueStatus(context.Context, *GetQueueStatusRequest) (*GetQueueStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetQueueStatus not implemented")
 }
+func (UnimplementedQueueManagementServiceServer) ListQueues(context.Context, *ListQueuesRequest) (*ListQueuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQueues not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) CallNext(context.Context, *CallNextRequest) (*CallNextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CallNext not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) StartService(context.Context, *StartServiceRequest) (*StartServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartService not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) CompleteService(context.Context, *CompleteServiceRequest) (*CompleteServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteService not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) MarkNoShow(context.Context, *MarkNoShowRequest) (*MarkNoShowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNoShow not implemented")
+}
+func (UnimplementedQueueManagementServiceServer) CancelTicket(context.Context, *CancelTicketRequest) (*CancelTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTicket not implemented")
+}
 func (UnimplementedQueueManagementServiceServer) mustEmbedUnimplementedQueueManagementServiceServer() {
 }
 
@@ -178,6 +354,96 @@ func _QueueManagementService_DeleteQueue_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueueManagementService_RestoreQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).RestoreQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_RestoreQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).RestoreQueue(ctx, req.(*RestoreQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_PauseQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).PauseQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_PauseQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).PauseQueue(ctx, req.(*PauseQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_CloseQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).CloseQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_CloseQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).CloseQueue(ctx, req.(*CloseQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_DrainQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).DrainQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_DrainQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).DrainQueue(ctx, req.(*DrainQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_ReopenQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReopenQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).ReopenQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_ReopenQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).ReopenQueue(ctx, req.(*ReopenQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QueueManagementService_GetQueueStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetQueueStatusRequest)
 	if err := dec(in); err != nil {
@@ -196,6 +462,114 @@ func _QueueManagementService_GetQueueStatus_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueueManagementService_ListQueues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).ListQueues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_ListQueues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).ListQueues(ctx, req.(*ListQueuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_CallNext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallNextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).CallNext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_CallNext_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).CallNext(ctx, req.(*CallNextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_StartService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).StartService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_StartService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).StartService(ctx, req.(*StartServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_CompleteService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).CompleteService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_CompleteService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).CompleteService(ctx, req.(*CompleteServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_MarkNoShow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkNoShowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).MarkNoShow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_MarkNoShow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).MarkNoShow(ctx, req.(*MarkNoShowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueueManagementService_CancelTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueueManagementServiceServer).CancelTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueueManagementService_CancelTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueueManagementServiceServer).CancelTicket(ctx, req.(*CancelTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // QueueManagementService_ServiceDesc is the grpc.ServiceDesc for QueueManagementService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -215,10 +589,341 @@ var QueueManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteQueue",
 			Handler:    _QueueManagementService_DeleteQueue_Handler,
 		},
+		{
+			MethodName: "RestoreQueue",
+			Handler:    _QueueManagementService_RestoreQueue_Handler,
+		},
+		{
+			MethodName: "PauseQueue",
+			Handler:    _QueueManagementService_PauseQueue_Handler,
+		},
+		{
+			MethodName: "CloseQueue",
+			Handler:    _QueueManagementService_CloseQueue_Handler,
+		},
+		{
+			MethodName: "DrainQueue",
+			Handler:    _QueueManagementService_DrainQueue_Handler,
+		},
+		{
+			MethodName: "ReopenQueue",
+			Handler:    _QueueManagementService_ReopenQueue_Handler,
+		},
 		{
 			MethodName: "GetQueueStatus",
 			Handler:    _QueueManagementService_GetQueueStatus_Handler,
 		},
+		{
+			MethodName: "ListQueues",
+			Handler:    _QueueManagementService_ListQueues_Handler,
+		},
+		{
+			MethodName: "CallNext",
+			Handler:    _QueueManagementService_CallNext_Handler,
+		},
+		{
+			MethodName: "StartService",
+			Handler:    _QueueManagementService_StartService_Handler,
+		},
+		{
+			MethodName: "CompleteService",
+			Handler:    _QueueManagementService_CompleteService_Handler,
+		},
+		{
+			MethodName: "MarkNoShow",
+			Handler:    _QueueManagementService_MarkNoShow_Handler,
+		},
+		{
+			MethodName: "CancelTicket",
+			Handler:    _QueueManagementService_CancelTicket_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "queue_management.proto",
+}
+
+const (
+	CounterService_CreateCounter_FullMethodName = "/queue.CounterService/CreateCounter"
+	CounterService_GetCounter_FullMethodName    = "/queue.CounterService/GetCounter"
+	CounterService_ListCounters_FullMethodName  = "/queue.CounterService/ListCounters"
+	CounterService_AssignCounter_FullMethodName = "/queue.CounterService/AssignCounter"
+	CounterService_OpenCounter_FullMethodName   = "/queue.CounterService/OpenCounter"
+	CounterService_CloseCounter_FullMethodName  = "/queue.CounterService/CloseCounter"
+)
+
+// CounterServiceClient is the client API for CounterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CounterService manages the counters (desks) calling clients. A counter
+// calls the clients of the queues it is assigned to, and only while open.
+type CounterServiceClient interface {
+	CreateCounter(ctx context.Context, in *CreateCounterRequest, opts ...grpc.CallOption) (*CreateCounterResponse, error)
+	GetCounter(ctx context.Context, in *GetCounterRequest, opts ...grpc.CallOption) (*GetCounterResponse, error)
+	ListCounters(ctx context.Context, in *ListCountersRequest, opts ...grpc.CallOption) (*ListCountersResponse, error)
+	AssignCounter(ctx context.Context, in *AssignCounterRequest, opts ...grpc.CallOption) (*AssignCounterResponse, error)
+	OpenCounter(ctx context.Context, in *OpenCounterRequest, opts ...grpc.CallOption) (*OpenCounterResponse, error)
+	CloseCounter(ctx context.Context, in *CloseCounterRequest, opts ...grpc.CallOption) (*CloseCounterResponse, error)
+}
+
+type counterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCounterServiceClient(cc grpc.ClientConnInterface) CounterServiceClient {
+	return &counterServiceClient{cc}
+}
+
+func (c *counterServiceClient) CreateCounter(ctx context.Context, in *CreateCounterRequest, opts ...grpc.CallOption) (*CreateCounterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCounterResponse)
+	err := c.cc.Invoke(ctx, CounterService_CreateCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *counterServiceClient) GetCounter(ctx context.Context, in *GetCounterRequest, opts ...grpc.CallOption) (*GetCounterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCounterResponse)
+	err := c.cc.Invoke(ctx, CounterService_GetCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *counterServiceClient) ListCounters(ctx context.Context, in *ListCountersRequest, opts ...grpc.CallOption) (*ListCountersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCountersResponse)
+	err := c.cc.Invoke(ctx, CounterService_ListCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *counterServiceClient) AssignCounter(ctx context.Context, in *AssignCounterRequest, opts ...grpc.CallOption) (*AssignCounterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignCounterResponse)
+	err := c.cc.Invoke(ctx, CounterService_AssignCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *counterServiceClient) OpenCounter(ctx context.Context, in *OpenCounterRequest, opts ...grpc.CallOption) (*OpenCounterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenCounterResponse)
+	err := c.cc.Invoke(ctx, CounterService_OpenCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *counterServiceClient) CloseCounter(ctx context.Context, in *CloseCounterRequest, opts ...grpc.CallOption) (*CloseCounterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseCounterResponse)
+	err := c.cc.Invoke(ctx, CounterService_CloseCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CounterServiceServer is the server API for CounterService service.
+// All implementations must embed UnimplementedCounterServiceServer
+// for forward compatibility
+//
+// CounterService manages the counters (desks) calling clients. A counter
+// calls the clients of the queues it is assigned to, and only while open.
+type CounterServiceServer interface {
+	CreateCounter(context.Context, *CreateCounterRequest) (*CreateCounterResponse, error)
+	GetCounter(context.Context, *GetCounterRequest) (*GetCounterResponse, error)
+	ListCounters(context.Context, *ListCountersRequest) (*ListCountersResponse, error)
+	AssignCounter(context.Context, *AssignCounterRequest) (*AssignCounterResponse, error)
+	OpenCounter(context.Context, *OpenCounterRequest) (*OpenCounterResponse, error)
+	CloseCounter(context.Context, *CloseCounterRequest) (*CloseCounterResponse, error)
+	mustEmbedUnimplementedCounterServiceServer()
+}
+
+// UnimplementedCounterServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCounterServiceServer struct {
+}
+
+func (UnimplementedCounterServiceServer) CreateCounter(context.Context, *CreateCounterRequest) (*CreateCounterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCounter not implemented")
+}
+func (UnimplementedCounterServiceServer) GetCounter(context.Context, *GetCounterRequest) (*GetCounterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCounter not implemented")
+}
+func (UnimplementedCounterServiceServer) ListCounters(context.Context, *ListCountersRequest) (*ListCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCounters not implemented")
+}
+func (UnimplementedCounterServiceServer) AssignCounter(context.Context, *AssignCounterRequest) (*AssignCounterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignCounter not implemented")
+}
+func (UnimplementedCounterServiceServer) OpenCounter(context.Context, *OpenCounterRequest) (*OpenCounterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenCounter not implemented")
+}
+func (UnimplementedCounterServiceServer) CloseCounter(context.Context, *CloseCounterRequest) (*CloseCounterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseCounter not implemented")
+}
+func (UnimplementedCounterServiceServer) mustEmbedUnimplementedCounterServiceServer() {}
+
+// UnsafeCounterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CounterServiceServer will
+// result in compilation errors.
+type UnsafeCounterServiceServer interface {
+	mustEmbedUnimplementedCounterServiceServer()
+}
+
+func RegisterCounterServiceServer(s grpc.ServiceRegistrar, srv CounterServiceServer) {
+	s.RegisterService(&CounterService_ServiceDesc, srv)
+}
+
+func _CounterService_CreateCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CounterServiceServer).CreateCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CounterService_CreateCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CounterServiceServer).CreateCounter(ctx, req.(*CreateCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CounterService_GetCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CounterServiceServer).GetCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CounterService_GetCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CounterServiceServer).GetCounter(ctx, req.(*GetCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CounterService_ListCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CounterServiceServer).ListCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CounterService_ListCounters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CounterServiceServer).ListCounters(ctx, req.(*ListCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CounterService_AssignCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CounterServiceServer).AssignCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CounterService_AssignCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CounterServiceServer).AssignCounter(ctx, req.(*AssignCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CounterService_OpenCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CounterServiceServer).OpenCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CounterService_OpenCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CounterServiceServer).OpenCounter(ctx, req.(*OpenCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CounterService_CloseCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CounterServiceServer).CloseCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CounterService_CloseCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CounterServiceServer).CloseCounter(ctx, req.(*CloseCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CounterService_ServiceDesc is the grpc.ServiceDesc for CounterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CounterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "queue.CounterService",
+	HandlerType: (*CounterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCounter",
+			Handler:    _CounterService_CreateCounter_Handler,
+		},
+		{
+			MethodName: "GetCounter",
+			Handler:    _CounterService_GetCounter_Handler,
+		},
+		{
+			MethodName: "ListCounters",
+			Handler:    _CounterService_ListCounters_Handler,
+		},
+		{
+			MethodName: "AssignCounter",
+			Handler:    _CounterService_AssignCounter_Handler,
+		},
+		{
+			MethodName: "OpenCounter",
+			Handler:    _CounterService_OpenCounter_Handler,
+		},
+		{
+			MethodName: "CloseCounter",
+			Handler:    _CounterService_CloseCounter_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "queue_management.proto",
@@ -0,0 +1,770 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"queue-management-system/queue-management-service/models"
+	"queue-management-system/queue-management-service/notifier"
+)
+
+// recentCalls is how many of the latest calls the weighted policy looks at,
+// the same window queue_positions uses.
+const recentCalls = 10
+
+// MemoryQueueRepository keeps queues in memory. Deleting a queue checks the
+// clients of the client repository created with it.
+type MemoryQueueRepository struct {
+	clients  *MemoryClientRepository
+	counters *MemoryCounterRepository
+	mu       sync.Mutex
+	queues   map[int32]*models.Queue
+	nextID   int32
+}
+
+func NewMemoryQueueRepository() *MemoryQueueRepository {
+	return &MemoryQueueRepository{queues: make(map[int32]*models.Queue)}
+}
+
+func (r *MemoryQueueRepository) Create(ctx context.Context, queue *models.Queue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	queue.ID = r.nextID
+	if queue.OverflowPolicy == "" {
+		queue.OverflowPolicy = models.OverflowReject
+	}
+	if queue.State == "" {
+		queue.State = models.QueueOpen
+	}
+	stored := *queue
+	r.queues[queue.ID] = &stored
+	return nil
+}
+
+func (r *MemoryQueueRepository) Get(ctx context.Context, id int32, includeDeleted bool) (*models.Queue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[id]
+	if !ok || (queue.DeletedAt != nil && !includeDeleted) {
+		return nil, ErrNotFound
+	}
+	copied := *queue
+	return &copied, nil
+}
+
+func (r *MemoryQueueRepository) List(ctx context.Context, filter QueueFilter) ([]*QueueSummary, error) {
+	// Lock in the order the client repository does when it reads queues.
+	if r.clients != nil {
+		r.clients.mu.Lock()
+		defer r.clients.mu.Unlock()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		statuses = []models.QueueStatus{models.QueueActive}
+	}
+	var queues []*QueueSummary
+	for _, queue := range r.queues {
+		if queue.ID <= filter.AfterID || !hasStatus(statuses, queue.Status()) ||
+			!strings.Contains(strings.ToLower(queue.Name), strings.ToLower(filter.NameContains)) {
+			continue
+		}
+		summary := &QueueSummary{Queue: *queue}
+		if r.clients != nil {
+			summary.Waiting, summary.AverageWaitSeconds = r.clients.load(queue.ID)
+		}
+		queues = append(queues, summary)
+	}
+	sort.Slice(queues, func(i, j int) bool { return queues[i].ID < queues[j].ID })
+	if filter.Limit > 0 && len(queues) > int(filter.Limit) {
+		queues = queues[:filter.Limit]
+	}
+	return queues, nil
+}
+
+func hasStatus(statuses []models.QueueStatus, status models.QueueStatus) bool {
+	for _, st := range statuses {
+		if st == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryQueueRepository) Update(ctx context.Context, u QueueUpdate) error {
+	// Lock in the order the client repository does when it reads queues.
+	if r.clients != nil {
+		r.clients.mu.Lock()
+		defer r.clients.mu.Unlock()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[u.ID]
+	if !ok || queue.DeletedAt != nil {
+		return ErrNotFound
+	}
+	queue.Name = u.Name
+	if u.OrderingPolicy != nil {
+		queue.OrderingPolicy = *u.OrderingPolicy
+	}
+	if u.AgingSeconds != nil {
+		queue.AgingSeconds = *u.AgingSeconds
+	}
+	if u.TicketPrefix != nil {
+		queue.TicketPrefix = *u.TicketPrefix
+	}
+	if u.TicketPadding != nil {
+		queue.TicketPadding = *u.TicketPadding
+	}
+	if u.TicketReset != nil {
+		queue.TicketReset = *u.TicketReset
+	}
+	if u.Schedule != nil || u.ClearSchedule {
+		queue.Schedule = u.Schedule
+		queue.ScheduleClosed = false
+	}
+	if u.MaxSize != nil {
+		queue.MaxSize = *u.MaxSize
+	}
+	if u.OverflowPolicy != nil {
+		queue.OverflowPolicy = *u.OverflowPolicy
+	}
+	if u.OverflowQueueID != nil {
+		queue.OverflowQueueID = *u.OverflowQueueID
+	}
+	if r.clients != nil {
+		r.clients.promoteWaitlisted(queue)
+	}
+	return nil
+}
+
+func (r *MemoryQueueRepository) Delete(ctx context.Context, id int32) (int64, error) {
+	// Lock in the order the client repository does when it reads queues.
+	if r.clients != nil {
+		r.clients.mu.Lock()
+		defer r.clients.mu.Unlock()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[id]
+	if !ok || queue.DeletedAt != nil {
+		return 0, ErrNotFound
+	}
+
+	var active, kept int64
+	if r.clients != nil {
+		for _, client := range r.clients.clients {
+			if client.QueueID != id {
+				continue
+			}
+			if !client.Status.IsFinished() {
+				active++
+			}
+			kept++
+		}
+	}
+	if active > 0 {
+		return 0, &ActiveClientsError{Count: active}
+	}
+
+	now := time.Now()
+	queue.DeletedAt = &now
+	return kept, nil
+}
+
+func (r *MemoryQueueRepository) Restore(ctx context.Context, id int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[id]
+	if !ok || queue.DeletedAt == nil {
+		return ErrNotFound
+	}
+	queue.DeletedAt = nil
+	return nil
+}
+
+func (r *MemoryQueueRepository) SetState(ctx context.Context, id int32, state models.QueueState, n *notifier.Notifier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[id]
+	if !ok || queue.DeletedAt != nil {
+		return ErrNotFound
+	}
+	queue.State = state
+	return nil
+}
+
+func (r *MemoryQueueRepository) ListScheduled(ctx context.Context) ([]*models.Queue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var queues []*models.Queue
+	for _, queue := range r.queues {
+		if queue.Schedule != nil && queue.DeletedAt == nil {
+			copied := *queue
+			queues = append(queues, &copied)
+		}
+	}
+	sort.Slice(queues, func(i, j int) bool { return queues[i].ID < queues[j].ID })
+	return queues, nil
+}
+
+func (r *MemoryQueueRepository) SetScheduleClosed(ctx context.Context, id int32, closed bool, n *notifier.Notifier) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue, ok := r.queues[id]
+	if !ok {
+		return false, nil
+	}
+	changed := queue.ScheduleClosed != closed
+	queue.ScheduleClosed = closed
+	return changed, nil
+}
+
+// MemoryClientRepository keeps clients in memory. It reads the ordering
+// policy of their queues from queues.
+type MemoryClientRepository struct {
+	queues   *MemoryQueueRepository
+	mu       sync.Mutex
+	clients  map[int32]*models.Client
+	archived map[int32]*models.Client
+	nextID   int32
+}
+
+func NewMemoryClientRepository(queues *MemoryQueueRepository) *MemoryClientRepository {
+	r := &MemoryClientRepository{
+		queues:   queues,
+		clients:  make(map[int32]*models.Client),
+		archived: make(map[int32]*models.Client),
+	}
+	queues.clients = r
+	return r
+}
+
+func (r *MemoryClientRepository) Create(ctx context.Context, client *models.Client) error {
+	if client.Status == "" {
+		client.Status = models.StatusWaiting
+	}
+	if client.JoinedAt.IsZero() {
+		client.JoinedAt = time.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.queues.Get(ctx, client.QueueID, true); err != nil {
+		return err
+	}
+	r.nextID++
+	client.ID = r.nextID
+	r.clients[client.ID] = copyClient(client)
+	return nil
+}
+
+func (r *MemoryClientRepository) Get(ctx context.Context, id int32) (*models.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	client, ok := r.clients[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyClient(client), nil
+}
+
+func (r *MemoryClientRepository) ListActive(ctx context.Context, queueID int32, filter ClientFilter) (*ClientPage, error) {
+	keys := filter.Sort
+	if len(keys) == 0 {
+		keys = defaultClientSort
+	}
+	if err := clientSortColumns.validate(keys); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	places := r.positions(ctx, queueID)
+	var clients []*QueuedClient
+	for _, client := range r.clients {
+		if client.QueueID != queueID || !client.Status.IsActive() {
+			continue
+		}
+		if filter.NameContains != "" && !strings.Contains(strings.ToLower(client.Name), strings.ToLower(filter.NameContains)) {
+			continue
+		}
+		clients = append(clients, &QueuedClient{Client: copyClient(client), Place: places[client.ID]})
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+		a, b := clients[i], clients[j]
+		if c := compareBy(keys, func(field SortField) int { return compareClients(a, b, field) }); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	})
+
+	page := &ClientPage{Total: int32(len(clients))}
+	if filter.Offset > 0 {
+		if int(filter.Offset) >= len(clients) {
+			return page, nil
+		}
+		clients = clients[filter.Offset:]
+	}
+	if filter.Limit > 0 && int(filter.Limit) < len(clients) {
+		clients = clients[:filter.Limit]
+	}
+	page.Clients = clients
+	return page, nil
+}
+
+// compareClients compares a and b by one of the clientSortColumns fields.
+func compareClients(a, b *QueuedClient, field SortField) int {
+	switch field {
+	case SortID:
+		return compareInts(int64(a.ID), int64(b.ID))
+	case SortName:
+		return strings.Compare(a.Name, b.Name)
+	case SortEmail:
+		return strings.Compare(a.Email, b.Email)
+	case SortTicketNumber:
+		return strings.Compare(a.TicketNumber, b.TicketNumber)
+	case SortStatus:
+		return strings.Compare(string(a.Status), string(b.Status))
+	case SortPriority:
+		return compareInts(int64(a.Priority), int64(b.Priority))
+	case SortJoinedAt:
+		return a.JoinedAt.Compare(b.JoinedAt)
+	case SortPosition:
+		return compareInts(int64(a.Place), int64(b.Place))
+	}
+	return 0
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (r *MemoryClientRepository) CountWaiting(ctx context.Context, queueID int32) (int32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var waiting int32
+	for _, client := range r.clients {
+		if client.QueueID == queueID && client.Status == models.StatusWaiting {
+			waiting++
+		}
+	}
+	return waiting, nil
+}
+
+func (r *MemoryClientRepository) CountWaitlisted(ctx context.Context, queueID int32) (int32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var waitlisted int32
+	for _, client := range r.clients {
+		if client.QueueID == queueID && client.Status == models.StatusWaitlisted {
+			waitlisted++
+		}
+	}
+	return waitlisted, nil
+}
+
+func (r *MemoryClientRepository) CallNext(ctx context.Context, queueID, counterID int32, n *notifier.Notifier) (*models.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queue, err := r.queues.Get(ctx, queueID, false)
+	if err != nil {
+		return nil, err
+	}
+	if !queue.State.CallsClients() {
+		return nil, ErrQueuePaused
+	}
+	if err := r.counterServes(ctx, counterID, queueID); err != nil {
+		return nil, err
+	}
+	var next *models.Client
+	for id, place := range r.positions(ctx, queueID) {
+		if place == 1 {
+			next = r.clients[id]
+		}
+	}
+	if next == nil {
+		return nil, ErrNotFound
+	}
+	now := time.Now()
+	next.Status = models.StatusCalled
+	next.CalledAt = &now
+	next.CounterID = counterID
+	r.promoteWaitlisted(queue)
+	return copyClient(next), nil
+}
+
+// counterServes returns nil when the counter is open and assigned to the
+// queue.
+func (r *MemoryClientRepository) counterServes(ctx context.Context, counterID, queueID int32) error {
+	if r.queues.counters == nil {
+		return ErrCounterNotAssigned
+	}
+	counter, err := r.queues.counters.Get(ctx, counterID)
+	switch {
+	case err == ErrNotFound || (err == nil && !counter.Serves(queueID)):
+		return ErrCounterNotAssigned
+	case err != nil:
+		return err
+	case !counter.Open:
+		return ErrCounterClosed
+	}
+	return nil
+}
+
+func (r *MemoryClientRepository) Transition(ctx context.Context, clientID int32, next models.ClientStatus, n *notifier.Notifier) (*models.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client, ok := r.clients[clientID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !client.Status.CanTransitionTo(next) {
+		return nil, &TransitionError{From: client.Status, To: next}
+	}
+	now := time.Now()
+	client.Status = next
+	switch next {
+	case models.StatusCalled:
+		client.CalledAt = &now
+	case models.StatusServing:
+		client.ServingStartedAt = &now
+	case models.StatusServed:
+		client.ServedAt = &now
+	case models.StatusNoShow:
+		client.NoShowAt = &now
+	case models.StatusCancelled:
+		client.CancelledAt = &now
+		if queue, err := r.queues.Get(ctx, client.QueueID, true); err == nil {
+			r.promoteWaitlisted(queue)
+		}
+	}
+	return copyClient(client), nil
+}
+
+func (r *MemoryClientRepository) ServiceDurations(ctx context.Context, queueID int32, limit int) ([]float64, error) {
+	r.mu.Lock()
+	var served []*models.Client
+	for _, clients := range []map[int32]*models.Client{r.clients, r.archived} {
+		for _, client := range clients {
+			if client.QueueID == queueID && client.Status == models.StatusServed && client.ServingStartedAt != nil && client.ServedAt != nil {
+				served = append(served, client)
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(served, func(i, j int) bool { return served[i].ServedAt.After(*served[j].ServedAt) })
+	if len(served) > limit {
+		served = served[:limit]
+	}
+	var newestFirst []float64
+	for _, client := range served {
+		newestFirst = append(newestFirst, client.ServedAt.Sub(*client.ServingStartedAt).Seconds())
+	}
+	return newestFirst, nil
+}
+
+func (r *MemoryClientRepository) OpenCounters(ctx context.Context, queueID int32) (int32, error) {
+	counters := r.queues.counters
+	if counters == nil {
+		return 0, nil
+	}
+	counters.mu.Lock()
+	defer counters.mu.Unlock()
+	var open int32
+	for _, counter := range counters.counters {
+		if counter.Open && counter.Serves(queueID) {
+			open++
+		}
+	}
+	return open, nil
+}
+
+func (r *MemoryClientRepository) Archive(ctx context.Context, finishedBefore time.Time, limit int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []int32
+	for id, client := range r.clients {
+		if finished := finishedAt(client); finished != nil && finished.Before(finishedBefore) {
+			due = append(due, id)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i] < due[j] })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	for _, id := range due {
+		r.archived[id] = r.clients[id]
+		delete(r.clients, id)
+	}
+	return int64(len(due)), nil
+}
+
+// load returns how many clients wait in a queue and how long the clients
+// called within AverageWaitWindow, archived or not, waited on average. r.mu
+// must be held.
+func (r *MemoryClientRepository) load(queueID int32) (int32, float64) {
+	var waiting, called int32
+	var waited float64
+	since := time.Now().Add(-AverageWaitWindow)
+	for _, client := range r.clients {
+		if client.QueueID != queueID {
+			continue
+		}
+		if client.Status == models.StatusWaiting {
+			waiting++
+		}
+		if client.CalledAt != nil && client.CalledAt.After(since) {
+			called++
+			waited += client.CalledAt.Sub(client.JoinedAt).Seconds()
+		}
+	}
+	for _, client := range r.archived {
+		if client.QueueID == queueID && client.CalledAt != nil && client.CalledAt.After(since) {
+			called++
+			waited += client.CalledAt.Sub(client.JoinedAt).Seconds()
+		}
+	}
+	if called == 0 {
+		return waiting, 0
+	}
+	return waiting, waited / float64(called)
+}
+
+// promoteWaitlisted moves waitlisted clients of queue to waiting, in the
+// order they registered, while the queue has room for them. r.mu must be
+// held.
+func (r *MemoryClientRepository) promoteWaitlisted(queue *models.Queue) {
+	var waiting int32
+	var waitlisted []*models.Client
+	for _, client := range r.clients {
+		if client.QueueID != queue.ID {
+			continue
+		}
+		switch client.Status {
+		case models.StatusWaiting:
+			waiting++
+		case models.StatusWaitlisted:
+			waitlisted = append(waitlisted, client)
+		}
+	}
+	sort.Slice(waitlisted, func(i, j int) bool {
+		a, b := waitlisted[i], waitlisted[j]
+		if !a.JoinedAt.Equal(b.JoinedAt) {
+			return a.JoinedAt.Before(b.JoinedAt)
+		}
+		return a.ID < b.ID
+	})
+	for _, client := range waitlisted {
+		if queue.MaxSize > 0 && waiting >= queue.MaxSize {
+			return
+		}
+		client.Status = models.StatusWaiting
+		waiting++
+	}
+}
+
+// finishedAt returns when a client reached its terminal state, or nil while
+// it is active.
+func finishedAt(client *models.Client) *time.Time {
+	switch client.Status {
+	case models.StatusServed:
+		return client.ServedAt
+	case models.StatusNoShow:
+		return client.NoShowAt
+	case models.StatusCancelled:
+		return client.CancelledAt
+	}
+	return nil
+}
+
+// positions returns the 1-based place of every waiting client of a queue,
+// ordered as queue_positions orders them. r.mu must be held.
+func (r *MemoryClientRepository) positions(ctx context.Context, queueID int32) map[int32]int32 {
+	queue, err := r.queues.Get(ctx, queueID, true)
+	if err != nil {
+		return nil
+	}
+
+	var waiting, called []*models.Client
+	for _, client := range r.clients {
+		if client.QueueID != queueID {
+			continue
+		}
+		if client.Status == models.StatusWaiting {
+			waiting = append(waiting, client)
+		}
+		if client.CalledAt != nil {
+			called = append(called, client)
+		}
+	}
+
+	// The weighted policy counts the tiers of the latest calls.
+	sort.Slice(called, func(i, j int) bool { return called[i].CalledAt.After(*called[j].CalledAt) })
+	if len(called) > recentCalls {
+		called = called[:recentCalls]
+	}
+	recent := make(map[int32]int)
+	for _, client := range called {
+		recent[client.Priority]++
+	}
+
+	byArrival := func(a, b *models.Client) bool {
+		if !a.JoinedAt.Equal(b.JoinedAt) {
+			return a.JoinedAt.Before(b.JoinedAt)
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(waiting, func(i, j int) bool { return byArrival(waiting[i], waiting[j]) })
+	tierRank := make(map[int32]int)
+	weight := make(map[int32]float64, len(waiting))
+	for _, client := range waiting {
+		tierRank[client.Priority]++
+		weight[client.ID] = float64(recent[client.Priority]+tierRank[client.Priority]) / float64(client.Priority+1)
+	}
+
+	now := time.Now()
+	effectivePriority := func(c *models.Client) float64 {
+		if queue.OrderingPolicy == models.OrderAging {
+			return float64(c.Priority) + math.Floor(now.Sub(c.JoinedAt).Seconds()/float64(queue.AgingSeconds))
+		}
+		return float64(c.Priority)
+	}
+	sort.SliceStable(waiting, func(i, j int) bool {
+		a, b := waiting[i], waiting[j]
+		if queue.OrderingPolicy == models.OrderWeighted && weight[a.ID] != weight[b.ID] {
+			return weight[a.ID] < weight[b.ID]
+		}
+		if pa, pb := effectivePriority(a), effectivePriority(b); pa != pb {
+			return pa > pb
+		}
+		return byArrival(a, b)
+	})
+
+	places := make(map[int32]int32, len(waiting))
+	for i, client := range waiting {
+		places[client.ID] = int32(i + 1)
+	}
+	return places
+}
+
+func copyClient(c *models.Client) *models.Client {
+	copied := *c
+	return &copied
+}
+
+// MemoryCounterRepository keeps counters in memory. Counters are assigned to
+// the queues of the queue repository it was created with.
+type MemoryCounterRepository struct {
+	queues   *MemoryQueueRepository
+	mu       sync.Mutex
+	counters map[int32]*models.Counter
+	nextID   int32
+}
+
+func NewMemoryCounterRepository(queues *MemoryQueueRepository) *MemoryCounterRepository {
+	r := &MemoryCounterRepository{queues: queues, counters: make(map[int32]*models.Counter)}
+	queues.counters = r
+	return r
+}
+
+func (r *MemoryCounterRepository) Create(ctx context.Context, counter *models.Counter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queueIDs, err := r.liveQueues(ctx, counter.QueueIDs)
+	if err != nil {
+		return err
+	}
+	r.nextID++
+	counter.ID = r.nextID
+	counter.QueueIDs = queueIDs
+	counter.CreatedAt = time.Now()
+	r.counters[counter.ID] = copyCounter(counter)
+	return nil
+}
+
+func (r *MemoryCounterRepository) Get(ctx context.Context, id int32) (*models.Counter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counter, ok := r.counters[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyCounter(counter), nil
+}
+
+func (r *MemoryCounterRepository) List(ctx context.Context, queueID int32) ([]*models.Counter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var counters []*models.Counter
+	for _, counter := range r.counters {
+		if queueID == 0 || counter.Serves(queueID) {
+			counters = append(counters, copyCounter(counter))
+		}
+	}
+	sort.Slice(counters, func(i, j int) bool { return counters[i].ID < counters[j].ID })
+	return counters, nil
+}
+
+func (r *MemoryCounterRepository) Assign(ctx context.Context, id int32, queueIDs []int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counter, ok := r.counters[id]
+	if !ok {
+		return ErrNotFound
+	}
+	queueIDs, err := r.liveQueues(ctx, queueIDs)
+	if err != nil {
+		return err
+	}
+	counter.QueueIDs = queueIDs
+	return nil
+}
+
+func (r *MemoryCounterRepository) SetOpen(ctx context.Context, id int32, open bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counter, ok := r.counters[id]
+	if !ok {
+		return ErrNotFound
+	}
+	counter.Open = open
+	return nil
+}
+
+// liveQueues returns queueIDs sorted and without duplicates, or ErrNotFound
+// when one of the queues is missing or deleted.
+func (r *MemoryCounterRepository) liveQueues(ctx context.Context, queueIDs []int32) ([]int32, error) {
+	queueIDs = sortedIDs(queueIDs)
+	for _, id := range queueIDs {
+		if _, err := r.queues.Get(ctx, id, false); err != nil {
+			return nil, err
+		}
+	}
+	return queueIDs, nil
+}
+
+func copyCounter(c *models.Counter) *models.Counter {
+	copied := *c
+	copied.QueueIDs = append([]int32(nil), c.QueueIDs...)
+	return &copied
+}
@@ -0,0 +1,887 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"queue-management-system/queue-management-service/models"
+	"queue-management-system/queue-management-service/notifier"
+	"queue-management-system/schedule"
+)
+
+// PostgresQueueRepository stores queues in the queues table.
+type PostgresQueueRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresQueueRepository(db *sql.DB) *PostgresQueueRepository {
+	return &PostgresQueueRepository{db: db}
+}
+
+func (r *PostgresQueueRepository) Create(ctx context.Context, queue *models.Queue) error {
+	scheduleParam, err := scheduleJSON(queue.Schedule)
+	if err != nil {
+		return err
+	}
+	if queue.OverflowPolicy == "" {
+		queue.OverflowPolicy = models.OverflowReject
+	}
+	if queue.State == "" {
+		queue.State = models.QueueOpen
+	}
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO queues (name, ordering_policy, aging_seconds, ticket_prefix, ticket_padding, ticket_reset, schedule,
+			max_size, overflow_policy, overflow_queue_id, state)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, 0), $11)
+		RETURNING id`,
+		queue.Name, queue.OrderingPolicy, queue.AgingSeconds, queue.TicketPrefix, queue.TicketPadding, queue.TicketReset, scheduleParam,
+		queue.MaxSize, queue.OverflowPolicy, queue.OverflowQueueID, queue.State).
+		Scan(&queue.ID)
+}
+
+// queueColumns is the column list scanned by scanQueue, for queues aliased q.
+const queueColumns = "q.id, q.name, q.ordering_policy, q.aging_seconds, q.ticket_prefix, q.ticket_padding, q.ticket_reset, q.deleted_at, q.schedule, q.schedule_closed, " +
+	"q.max_size, q.overflow_policy, COALESCE(q.overflow_queue_id, 0), q.state"
+
+func scanQueue(row rowScanner) (*models.Queue, error) {
+	var queue models.Queue
+	var deletedAt sql.NullTime
+	var scheduleData []byte
+	err := row.Scan(&queue.ID, &queue.Name, &queue.OrderingPolicy, &queue.AgingSeconds, &queue.TicketPrefix, &queue.TicketPadding, &queue.TicketReset,
+		&deletedAt, &scheduleData, &queue.ScheduleClosed, &queue.MaxSize, &queue.OverflowPolicy, &queue.OverflowQueueID, &queue.State)
+	if err != nil {
+		return nil, err
+	}
+	queue.DeletedAt = nullTime(deletedAt)
+	if scheduleData != nil {
+		queue.Schedule = &schedule.Schedule{}
+		if err := json.Unmarshal(scheduleData, queue.Schedule); err != nil {
+			return nil, fmt.Errorf("queue %d: invalid schedule: %w", queue.ID, err)
+		}
+	}
+	return &queue, nil
+}
+
+// scheduleJSON encodes a schedule for the schedule column, nil for NULL.
+func scheduleJSON(s *schedule.Schedule) (interface{}, error) {
+	if s == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (r *PostgresQueueRepository) Get(ctx context.Context, id int32, includeDeleted bool) (*models.Queue, error) {
+	queue, err := scanQueue(r.db.QueryRowContext(ctx,
+		"SELECT "+queueColumns+" FROM queues q WHERE q.id = $1 AND ($2 OR q.deleted_at IS NULL)", id, includeDeleted))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return queue, err
+}
+
+func (r *PostgresQueueRepository) List(ctx context.Context, filter QueueFilter) ([]*QueueSummary, error) {
+	query := `SELECT ` + queueColumns + `,
+			COUNT(c.id) FILTER (WHERE c.status = 'waiting'),
+			COALESCE(AVG(EXTRACT(EPOCH FROM c.called_at - c.created_at))
+				FILTER (WHERE c.called_at > NOW() - $2 * INTERVAL '1 second'), 0)
+		FROM queues q LEFT JOIN (
+			SELECT id, queue_id, status, created_at, called_at FROM clients
+			UNION ALL
+			-- Clients called within the window may have been archived already.
+			SELECT id, queue_id, status, created_at, called_at FROM clients_archive
+			WHERE called_at > NOW() - $2 * INTERVAL '1 second'
+		) c ON c.queue_id = q.id
+		WHERE q.id > $1`
+	args := []interface{}{filter.AfterID, AverageWaitWindow.Seconds()}
+
+	paramIndex := 3
+
+	if filter.NameContains != "" {
+		query += fmt.Sprintf(` AND q.name ILIKE $%d ESCAPE '\'`, paramIndex)
+		args = append(args, containsPattern(filter.NameContains))
+		paramIndex++
+	}
+
+	active, deleted := true, false
+	if len(filter.Statuses) > 0 {
+		active, deleted = false, false
+		for _, st := range filter.Statuses {
+			active = active || st == models.QueueActive
+			deleted = deleted || st == models.QueueDeleted
+		}
+	}
+	switch {
+	case active && !deleted:
+		query += " AND q.deleted_at IS NULL"
+	case deleted && !active:
+		query += " AND q.deleted_at IS NOT NULL"
+	case !active && !deleted:
+		return nil, nil
+	}
+
+	order, err := queueSortColumns.orderBy([]SortKey{{Field: SortID}}, "")
+	if err != nil {
+		return nil, err
+	}
+	query += " GROUP BY q.id" + order
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", paramIndex)
+		args = append(args, filter.Limit)
+		paramIndex++
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var queues []*QueueSummary
+	for rows.Next() {
+		var q QueueSummary
+		queue, err := scanQueue(scanExtra{rows, []interface{}{&q.Waiting, &q.AverageWaitSeconds}})
+		if err != nil {
+			return nil, err
+		}
+		q.Queue = *queue
+		queues = append(queues, &q)
+	}
+	return queues, rows.Err()
+}
+
+func (r *PostgresQueueRepository) Update(ctx context.Context, u QueueUpdate) error {
+	scheduleParam, err := scheduleJSON(u.Schedule)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Unset fields are passed as NULL so COALESCE keeps the current value.
+	// A changed schedule is evaluated afresh by the scheduler.
+	result, err := tx.ExecContext(ctx, `
+		UPDATE queues SET name = $1,
+			ordering_policy = COALESCE($3, ordering_policy),
+			aging_seconds = COALESCE($4, aging_seconds),
+			ticket_prefix = COALESCE($5, ticket_prefix),
+			ticket_padding = COALESCE($6, ticket_padding),
+			ticket_reset = COALESCE($7, ticket_reset),
+			schedule = CASE WHEN $9 THEN NULL ELSE COALESCE($8::jsonb, schedule) END,
+			schedule_closed = CASE WHEN $9 OR $8 IS NOT NULL THEN FALSE ELSE schedule_closed END,
+			max_size = COALESCE($10, max_size),
+			overflow_policy = COALESCE($11, overflow_policy),
+			overflow_queue_id = CASE WHEN $12::int IS NULL THEN overflow_queue_id ELSE NULLIF($12, 0) END
+		WHERE id = $2 AND deleted_at IS NULL`, u.Name, u.ID, nullable(u.OrderingPolicy), nullable(u.AgingSeconds),
+		nullable(u.TicketPrefix), nullable(u.TicketPadding), nullable(u.TicketReset), scheduleParam, u.ClearSchedule,
+		nullable(u.MaxSize), nullable(u.OverflowPolicy), nullable(u.OverflowQueueID))
+	if err != nil {
+		return err
+	}
+	if err := requireRow(result); err != nil {
+		return err
+	}
+	// A larger queue has room for waitlisted clients.
+	if _, err := promoteWaitlisted(ctx, tx, u.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// promoteWaitlisted moves waitlisted clients of a queue to waiting, in the
+// order they registered, while the queue has room for them, and returns how
+// many it moved. Locking the queue row serializes it with registrations.
+func promoteWaitlisted(ctx context.Context, tx *sql.Tx, queueID int32) (int64, error) {
+	var maxSize int32
+	err := tx.QueryRowContext(ctx, "SELECT max_size FROM queues WHERE id = $1 FOR NO KEY UPDATE", queueID).Scan(&maxSize)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// Without a cap every waitlisted client fits; LIMIT NULL is no limit.
+	var room interface{}
+	if maxSize > 0 {
+		var waiting int32
+		err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM clients WHERE queue_id = $1 AND status = 'waiting'", queueID).Scan(&waiting)
+		if err != nil {
+			return 0, err
+		}
+		if waiting >= maxSize {
+			return 0, nil
+		}
+		room = maxSize - waiting
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE clients SET status = 'waiting'
+		WHERE id IN (
+			SELECT id FROM clients WHERE queue_id = $1 AND status = 'waitlisted'
+			ORDER BY created_at, id LIMIT $2
+		)`, queueID, room)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *PostgresQueueRepository) Delete(ctx context.Context, id int32) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Locking the queue row blocks clients from joining it until the
+	// transaction ends: their foreign key needs a share lock on it.
+	var locked int32
+	err = tx.QueryRowContext(ctx, "SELECT id FROM queues WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var active, kept int64
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FILTER (WHERE status <> ALL($2)), COUNT(*) FROM clients WHERE queue_id = $1",
+		id, pq.Array(statusStrings(models.FinishedStatuses))).Scan(&active, &kept)
+	if err != nil {
+		return 0, err
+	}
+	if active > 0 {
+		return 0, &ActiveClientsError{Count: active}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE queues SET deleted_at = NOW() WHERE id = $1", id); err != nil {
+		return 0, err
+	}
+	return kept, tx.Commit()
+}
+
+func (r *PostgresQueueRepository) Restore(ctx context.Context, id int32) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE queues SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// SetState tells the waiting clients in the transaction changing the state,
+// so a state change and its notice are committed together or not at all.
+func (r *PostgresQueueRepository) SetState(ctx context.Context, id int32, state models.QueueState, n *notifier.Notifier) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queue, err := scanQueue(tx.QueryRowContext(ctx,
+		"UPDATE queues q SET state = $2 WHERE q.id = $1 AND q.deleted_at IS NULL RETURNING "+queueColumns, id, state))
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := n.QueueClosed(ctx, tx, queue, notifier.ClosingDrain); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	n.Wake()
+	return nil
+}
+
+func (r *PostgresQueueRepository) ListScheduled(ctx context.Context) ([]*models.Queue, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+queueColumns+" FROM queues q WHERE q.schedule IS NOT NULL AND q.deleted_at IS NULL ORDER BY q.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var queues []*models.Queue
+	for rows.Next() {
+		queue, err := scanQueue(rows)
+		if err != nil {
+			return nil, err
+		}
+		queues = append(queues, queue)
+	}
+	return queues, rows.Err()
+}
+
+// SetScheduleClosed tells the waiting clients in the transaction closing the
+// queue, so a failed notice leaves the queue open for the next attempt.
+func (r *PostgresQueueRepository) SetScheduleClosed(ctx context.Context, id int32, closed bool, n *notifier.Notifier) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	queue, err := scanQueue(tx.QueryRowContext(ctx,
+		"UPDATE queues q SET schedule_closed = $2 WHERE q.id = $1 AND q.schedule_closed <> $2 RETURNING "+queueColumns, id, closed))
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if closed {
+		if err := n.QueueClosed(ctx, tx, queue, notifier.ClosingSchedule); err != nil {
+			return false, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	n.Wake()
+	return true, nil
+}
+
+// requireRow returns ErrNotFound when a statement changed no rows.
+func requireRow(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// isForeignKeyViolation reports whether err is PostgreSQL rejecting a row
+// that references a missing one.
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+// containsPattern returns the LIKE pattern matching names that contain s,
+// with the wildcards in s matched literally.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// statusStrings converts statuses to strings for an array parameter.
+func statusStrings(statuses []models.ClientStatus) []string {
+	strs := make([]string, len(statuses))
+	for i, st := range statuses {
+		strs[i] = string(st)
+	}
+	return strs
+}
+
+// nullable returns the value v points to, or nil for a NULL parameter.
+func nullable[T any](v *T) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// PostgresClientRepository stores clients in the clients table and orders
+// them with the queue_positions function. The client service reports places
+// from the function too, so it must keep returning (client_id, place) for
+// the waiting clients of a queue; see migration 000012.
+type PostgresClientRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresClientRepository(db *sql.DB) *PostgresClientRepository {
+	return &PostgresClientRepository{db: db}
+}
+
+// clientColumns is the column list scanned by scanClient.
+const clientColumns = "id, queue_id, name, email, ticket_number, status, priority, created_at, called_at, serving_started_at, served_at, no_show_at, cancelled_at, counter_id"
+
+// statusTimestampColumns maps each non-initial state to the column recording
+// when a client entered it.
+var statusTimestampColumns = map[models.ClientStatus]string{
+	models.StatusCalled:    "called_at",
+	models.StatusServing:   "serving_started_at",
+	models.StatusServed:    "served_at",
+	models.StatusNoShow:    "no_show_at",
+	models.StatusCancelled: "cancelled_at",
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanExtra scans columns following the client columns into extra.
+type scanExtra struct {
+	row   rowScanner
+	extra []interface{}
+}
+
+func (s scanExtra) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+func scanClient(row rowScanner) (*models.Client, error) {
+	var client models.Client
+	var calledAt, servingStartedAt, servedAt, noShowAt, cancelledAt sql.NullTime
+	var counterID sql.NullInt32
+	err := row.Scan(&client.ID, &client.QueueID, &client.Name, &client.Email, &client.TicketNumber, &client.Status, &client.Priority, &client.JoinedAt,
+		&calledAt, &servingStartedAt, &servedAt, &noShowAt, &cancelledAt, &counterID)
+	if err != nil {
+		return nil, err
+	}
+	client.CalledAt = nullTime(calledAt)
+	client.ServingStartedAt = nullTime(servingStartedAt)
+	client.ServedAt = nullTime(servedAt)
+	client.NoShowAt = nullTime(noShowAt)
+	client.CancelledAt = nullTime(cancelledAt)
+	client.CounterID = counterID.Int32
+	return &client, nil
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func (r *PostgresClientRepository) Create(ctx context.Context, client *models.Client) error {
+	if client.Status == "" {
+		client.Status = models.StatusWaiting
+	}
+	var joinedAt, counterID interface{}
+	if !client.JoinedAt.IsZero() {
+		joinedAt = client.JoinedAt
+	}
+	if client.CounterID != 0 {
+		counterID = client.CounterID
+	}
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO clients (queue_id, name, email, ticket_number, status, priority, created_at,
+			called_at, serving_started_at, served_at, no_show_at, cancelled_at, counter_id)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, NOW()), $8, $9, $10, $11, $12, $13)
+		RETURNING id, created_at`,
+		client.QueueID, client.Name, client.Email, client.TicketNumber, client.Status, client.Priority, joinedAt,
+		client.CalledAt, client.ServingStartedAt, client.ServedAt, client.NoShowAt, client.CancelledAt, counterID).
+		Scan(&client.ID, &client.JoinedAt)
+	if isForeignKeyViolation(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (r *PostgresClientRepository) Get(ctx context.Context, id int32) (*models.Client, error) {
+	client, err := scanClient(r.db.QueryRowContext(ctx, "SELECT "+clientColumns+" FROM clients WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return client, err
+}
+
+func (r *PostgresClientRepository) ListActive(ctx context.Context, queueID int32, filter ClientFilter) (*ClientPage, error) {
+	// Waiting clients carry their place under the queue's ordering policy;
+	// called and serving clients have none and are listed first. The window
+	// count is taken before LIMIT and OFFSET apply.
+	query := `SELECT c.id, c.queue_id, c.name, c.email, c.ticket_number, c.status, c.priority, c.created_at,
+			c.called_at, c.serving_started_at, c.served_at, c.no_show_at, c.cancelled_at, c.counter_id,
+			COALESCE(p.place, 0), COUNT(*) OVER ()
+		FROM clients c LEFT JOIN queue_positions($1) p ON p.client_id = c.id
+		WHERE c.queue_id = $1 AND c.status = ANY($2)`
+	args := []interface{}{queueID, pq.Array(statusStrings(models.ActiveStatuses))}
+
+	paramIndex := 3
+
+	if filter.NameContains != "" {
+		query += fmt.Sprintf(` AND c.name ILIKE $%d ESCAPE '\'`, paramIndex)
+		args = append(args, containsPattern(filter.NameContains))
+		paramIndex++
+	}
+
+	keys := filter.Sort
+	if len(keys) == 0 {
+		keys = defaultClientSort
+	}
+	order, err := clientSortColumns.orderBy(keys, "c.id")
+	if err != nil {
+		return nil, err
+	}
+	query += order
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", paramIndex)
+		args = append(args, filter.Limit)
+		paramIndex++
+	}
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", paramIndex)
+		args = append(args, filter.Offset)
+		paramIndex++
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &ClientPage{}
+	for rows.Next() {
+		var place int32
+		client, err := scanClient(scanExtra{rows, []interface{}{&place, &page.Total}})
+		if err != nil {
+			return nil, err
+		}
+		page.Clients = append(page.Clients, &QueuedClient{Client: client, Place: place})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(page.Clients) == 0 && filter.Offset > 0 {
+		// Past the last page no row carries the count.
+		countQuery := "SELECT COUNT(*) FROM clients c WHERE c.queue_id = $1 AND c.status = ANY($2)"
+		countArgs := args[:2]
+		if filter.NameContains != "" {
+			countQuery += ` AND c.name ILIKE $3 ESCAPE '\'`
+			countArgs = args[:3]
+		}
+		if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&page.Total); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (r *PostgresClientRepository) CountWaiting(ctx context.Context, queueID int32) (int32, error) {
+	var waiting int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM clients WHERE queue_id = $1 AND status = 'waiting'", queueID).Scan(&waiting)
+	return waiting, err
+}
+
+func (r *PostgresClientRepository) CountWaitlisted(ctx context.Context, queueID int32) (int32, error) {
+	var waitlisted int32
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM clients WHERE queue_id = $1 AND status = 'waitlisted'", queueID).Scan(&waitlisted)
+	return waitlisted, err
+}
+
+// CallNext picks the row with FOR UPDATE SKIP LOCKED, so concurrent calls
+// skip clients another counter is calling instead of waiting for them.
+func (r *PostgresClientRepository) CallNext(ctx context.Context, queueID, counterID int32, n *notifier.Notifier) (*models.Client, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// The queue stays locked until the call commits, so a concurrent
+	// SetState either waits for the call or is seen by it.
+	var state models.QueueState
+	err = tx.QueryRowContext(ctx, "SELECT state FROM queues WHERE id = $1 FOR NO KEY UPDATE", queueID).Scan(&state)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !state.CallsClients() {
+		return nil, ErrQueuePaused
+	}
+	// Closing or reassigning the counter waits for the call likewise.
+	var open, assigned bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT c.open, EXISTS (SELECT 1 FROM counter_queues cq WHERE cq.counter_id = c.id AND cq.queue_id = $2)
+		FROM counters c WHERE c.id = $1 FOR SHARE`, counterID, queueID).Scan(&open, &assigned)
+	switch {
+	case err == sql.ErrNoRows || (err == nil && !assigned):
+		return nil, ErrCounterNotAssigned
+	case err != nil:
+		return nil, err
+	case !open:
+		return nil, ErrCounterClosed
+	}
+
+	row := tx.QueryRowContext(ctx, `
+		UPDATE clients SET status = 'called', called_at = NOW(), counter_id = $2
+		WHERE id = (
+			SELECT c.id FROM clients c
+			JOIN queue_positions($1) p ON p.client_id = c.id
+			WHERE c.status = 'waiting'
+			ORDER BY p.place
+			LIMIT 1
+			FOR UPDATE OF c SKIP LOCKED
+		)
+		RETURNING `+clientColumns,
+		queueID, counterID)
+
+	client, err := scanClient(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := promoteWaitlisted(ctx, tx, queueID); err != nil {
+		return nil, err
+	}
+	if err := n.ClientCalled(ctx, tx, client); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	n.Wake()
+	return client, nil
+}
+
+// Transition checks the current state in the UPDATE itself so concurrent
+// transitions cannot both succeed.
+func (r *PostgresClientRepository) Transition(ctx context.Context, clientID int32, next models.ClientStatus, n *notifier.Notifier) (*models.Client, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("UPDATE clients SET status = $1, %s = NOW() WHERE id = $2 AND status = ANY($3) RETURNING %s",
+		statusTimestampColumns[next], clientColumns)
+	client, err := scanClient(tx.QueryRowContext(ctx, query, next, clientID, pq.Array(statusStrings(models.PreviousStatuses(next)))))
+	if err == sql.ErrNoRows {
+		var current models.ClientStatus
+		if err := tx.QueryRowContext(ctx, "SELECT status FROM clients WHERE id = $1", clientID).Scan(&current); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+		return nil, &TransitionError{From: current, To: next}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Clients behind a cancelled waiting client move up, and the first
+	// waitlisted one joins them.
+	if next == models.StatusCancelled {
+		if _, err := promoteWaitlisted(ctx, tx, client.QueueID); err != nil {
+			return nil, err
+		}
+		if err := n.QueueMoved(ctx, tx, client.QueueID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	n.Wake()
+	return client, nil
+}
+
+func (r *PostgresClientRepository) ServiceDurations(ctx context.Context, queueID int32, limit int) ([]float64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT EXTRACT(EPOCH FROM served_at - serving_started_at) FROM (
+			SELECT served_at, serving_started_at FROM clients
+			WHERE queue_id = $1 AND status = 'served' AND serving_started_at IS NOT NULL
+			UNION ALL
+			-- Served clients are archived after a while but still count.
+			SELECT served_at, serving_started_at FROM clients_archive
+			WHERE queue_id = $1 AND status = 'served' AND serving_started_at IS NOT NULL
+		) served
+		ORDER BY served_at DESC
+		LIMIT $2`, queueID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var newestFirst []float64
+	for rows.Next() {
+		var seconds float64
+		if err := rows.Scan(&seconds); err != nil {
+			return nil, err
+		}
+		newestFirst = append(newestFirst, seconds)
+	}
+	return newestFirst, rows.Err()
+}
+
+func (r *PostgresClientRepository) OpenCounters(ctx context.Context, queueID int32) (int32, error) {
+	var counters int32
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM counters c
+		JOIN counter_queues cq ON cq.counter_id = c.id
+		WHERE cq.queue_id = $1 AND c.open`, queueID).Scan(&counters)
+	return counters, err
+}
+
+// Archive moves clients in one statement, so every client ends up in
+// exactly one of the tables. Clients locked by a concurrent transition are
+// left for the next run.
+func (r *PostgresClientRepository) Archive(ctx context.Context, finishedBefore time.Time, limit int) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		WITH due AS (
+			SELECT id FROM clients
+			WHERE status = ANY($1) AND COALESCE(served_at, no_show_at, cancelled_at) < $2
+			ORDER BY id LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		), moved AS (
+			DELETE FROM clients WHERE id IN (SELECT id FROM due)
+			RETURNING `+clientColumns+`
+		)
+		INSERT INTO clients_archive (`+clientColumns+`)
+		SELECT `+clientColumns+` FROM moved`,
+		pq.Array(statusStrings(models.FinishedStatuses)), finishedBefore, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// PostgresCounterRepository stores counters in the counters table and their
+// queues in counter_queues.
+type PostgresCounterRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCounterRepository(db *sql.DB) *PostgresCounterRepository {
+	return &PostgresCounterRepository{db: db}
+}
+
+func (r *PostgresCounterRepository) Create(ctx context.Context, counter *models.Counter) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, "INSERT INTO counters (name, open) VALUES ($1, $2) RETURNING id, created_at", counter.Name, counter.Open).
+		Scan(&counter.ID, &counter.CreatedAt)
+	if err != nil {
+		return err
+	}
+	counter.QueueIDs = sortedIDs(counter.QueueIDs)
+	if err := assignQueues(ctx, tx, counter.ID, counter.QueueIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// counterSelect reads counters, c, with their queues, sorted, as an array.
+const counterSelect = `
+	SELECT c.id, c.name, c.open, c.created_at,
+		COALESCE(array_agg(cq.queue_id ORDER BY cq.queue_id) FILTER (WHERE cq.queue_id IS NOT NULL), '{}')
+	FROM counters c
+	LEFT JOIN counter_queues cq ON cq.counter_id = c.id`
+
+func scanCounter(row rowScanner) (*models.Counter, error) {
+	var counter models.Counter
+	if err := row.Scan(&counter.ID, &counter.Name, &counter.Open, &counter.CreatedAt, pq.Array(&counter.QueueIDs)); err != nil {
+		return nil, err
+	}
+	if len(counter.QueueIDs) == 0 {
+		counter.QueueIDs = nil
+	}
+	return &counter, nil
+}
+
+func (r *PostgresCounterRepository) Get(ctx context.Context, id int32) (*models.Counter, error) {
+	counter, err := scanCounter(r.db.QueryRowContext(ctx, counterSelect+" WHERE c.id = $1 GROUP BY c.id", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return counter, err
+}
+
+func (r *PostgresCounterRepository) List(ctx context.Context, queueID int32) ([]*models.Counter, error) {
+	rows, err := r.db.QueryContext(ctx, counterSelect+`
+		WHERE $1 = 0 OR c.id IN (SELECT counter_id FROM counter_queues WHERE queue_id = $1)
+		GROUP BY c.id ORDER BY c.id`, queueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counters []*models.Counter
+	for rows.Next() {
+		counter, err := scanCounter(rows)
+		if err != nil {
+			return nil, err
+		}
+		counters = append(counters, counter)
+	}
+	return counters, rows.Err()
+}
+
+func (r *PostgresCounterRepository) Assign(ctx context.Context, id int32, queueIDs []int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Locking the counter serializes concurrent assignments.
+	var locked int32
+	err = tx.QueryRowContext(ctx, "SELECT id FROM counters WHERE id = $1 FOR UPDATE", id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM counter_queues WHERE counter_id = $1", id); err != nil {
+		return err
+	}
+	if err := assignQueues(ctx, tx, id, sortedIDs(queueIDs)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// assignQueues assigns a counter to queueIDs, which must be distinct. It
+// returns ErrNotFound when one of them is missing or deleted.
+func assignQueues(ctx context.Context, tx *sql.Tx, counterID int32, queueIDs []int32) error {
+	if len(queueIDs) == 0 {
+		return nil
+	}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO counter_queues (counter_id, queue_id)
+		SELECT $1, id FROM queues WHERE id = ANY($2) AND deleted_at IS NULL`, counterID, pq.Array(queueIDs))
+	if err != nil {
+		return err
+	}
+	assigned, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if assigned != int64(len(queueIDs)) {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCounterRepository) SetOpen(ctx context.Context, id int32, open bool) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE counters SET open = $2 WHERE id = $1", id, open)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
@@ -0,0 +1,231 @@
+// Package repository stores queues and their clients. The gRPC server only
+// talks to the QueueRepository and ClientRepository interfaces; PostgreSQL
+// backs them in production and the in-memory implementations serve tests
+// and local development.
+//
+// Methods making changes that clients are alerted of take the
+// *notifier.Notifier to alert them through. PostgreSQL enqueues the alerts
+// in the transaction of the change; memory alerts nobody, and neither does a
+// nil notifier.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"queue-management-system/queue-management-service/models"
+	"queue-management-system/queue-management-service/notifier"
+	"queue-management-system/schedule"
+)
+
+var (
+	// ErrNotFound is returned when the requested queue or client does not
+	// exist, and by CallNext when nobody is waiting.
+	ErrNotFound = errors.New("not found")
+	// ErrUnknownSortField is returned for a listing sorted by a field it
+	// does not support.
+	ErrUnknownSortField = errors.New("unknown sort field")
+	// ErrQueuePaused is returned by CallNext while the state of the queue
+	// keeps it from calling clients.
+	ErrQueuePaused = errors.New("queue is paused")
+	// ErrCounterClosed is returned by CallNext for a closed counter.
+	ErrCounterClosed = errors.New("counter is closed")
+	// ErrCounterNotAssigned is returned by CallNext for a counter that does
+	// not exist or is not assigned to the queue.
+	ErrCounterNotAssigned = errors.New("counter is not assigned to the queue")
+)
+
+// AverageWaitWindow is how far back List looks for calls when averaging the
+// wait of a queue.
+const AverageWaitWindow = 24 * time.Hour
+
+// TransitionError is returned when a client cannot move to the requested
+// state from the state it is in.
+type TransitionError struct {
+	From models.ClientStatus
+	To   models.ClientStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot move client from %s to %s", e.From, e.To)
+}
+
+// ActiveClientsError is returned when deleting a queue that clients still
+// occupy.
+type ActiveClientsError struct {
+	Count int64
+}
+
+func (e *ActiveClientsError) Error() string {
+	return fmt.Sprintf("queue has %d active clients", e.Count)
+}
+
+// QueueUpdate changes a queue. Nil fields keep their current value.
+type QueueUpdate struct {
+	ID             int32
+	Name           string
+	OrderingPolicy *models.OrderingPolicy
+	AgingSeconds   *int32
+	TicketPrefix   *string
+	TicketPadding  *int32
+	TicketReset    *models.TicketReset
+	// Schedule replaces the opening hours when set, and ClearSchedule
+	// removes them, leaving the queue always open.
+	Schedule       *schedule.Schedule
+	ClearSchedule  bool
+	MaxSize        *int32
+	OverflowPolicy *models.OverflowPolicy
+	// OverflowQueueID set to zero removes the overflow queue.
+	OverflowQueueID *int32
+}
+
+// QueueFilter narrows the queues returned by List.
+type QueueFilter struct {
+	// NameContains keeps queues whose name contains it, ignoring case.
+	NameContains string
+	// Statuses keeps queues in one of them. Without it only active queues
+	// are listed.
+	Statuses []models.QueueStatus
+	// AfterID continues a listing after the queue with this ID. Queues are
+	// listed by ID, so queues created meanwhile only ever show up on later
+	// pages.
+	AfterID int32
+	Limit   int32
+}
+
+// QueueSummary is a queue together with its current load.
+type QueueSummary struct {
+	models.Queue
+	Waiting int32
+	// AverageWaitSeconds is how long the clients called within
+	// AverageWaitWindow waited on average, zero without calls. Clients
+	// archived since count too.
+	AverageWaitSeconds float64
+}
+
+// ClientFilter narrows and orders the clients returned by ListActive.
+type ClientFilter struct {
+	// NameContains keeps clients whose name contains it, ignoring case.
+	NameContains string
+	// Sort orders the clients by the fields of clientSortColumns. Without
+	// it clients are in queue order: called and serving clients first, then
+	// waiting clients by their place.
+	Sort   []SortKey
+	Limit  int32
+	Offset int32
+}
+
+// QueuedClient is an active client with its 1-based place among the waiting
+// clients of its queue. Called and serving clients have no place.
+type QueuedClient struct {
+	*models.Client
+	Place int32
+}
+
+// ClientPage is one page of ListActive.
+type ClientPage struct {
+	Clients []*QueuedClient
+	// Total counts the clients matching the filter on all pages.
+	Total int32
+}
+
+type QueueRepository interface {
+	// Create stores a new queue and sets its ID.
+	Create(ctx context.Context, queue *models.Queue) error
+	// Get returns a queue. Deleted queues are only returned with
+	// includeDeleted.
+	Get(ctx context.Context, id int32, includeDeleted bool) (*models.Queue, error)
+	// List returns the queues matching filter ordered by ID.
+	List(ctx context.Context, filter QueueFilter) ([]*QueueSummary, error)
+	// Update changes a queue. Waitlisted clients that fit in the queue
+	// afterwards are promoted to waiting.
+	Update(ctx context.Context, update QueueUpdate) error
+	// Delete marks a queue deleted, keeping it and the clients it has
+	// finished with for reporting, and returns how many clients it kept. It
+	// fails with an *ActiveClientsError while clients are still waiting,
+	// called or being served.
+	Delete(ctx context.Context, id int32) (int64, error)
+	// Restore undoes Delete. It returns ErrNotFound when no deleted queue
+	// has the ID.
+	Restore(ctx context.Context, id int32) error
+	// SetState moves a live queue to state. With a notifier the clients
+	// waiting in the queue are told, along with the change, that it closed
+	// for new clients. It returns ErrNotFound when no live queue has the ID.
+	SetState(ctx context.Context, id int32, state models.QueueState, n *notifier.Notifier) error
+	// ListScheduled returns the live queues that have a schedule.
+	ListScheduled(ctx context.Context) ([]*models.Queue, error)
+	// SetScheduleClosed records whether the schedule keeps a queue closed.
+	// It reports false when the queue was already in that state, so only
+	// one scheduler acts on each change. With a notifier, closing tells the
+	// clients waiting in the queue that it closed for new clients.
+	SetScheduleClosed(ctx context.Context, id int32, closed bool, n *notifier.Notifier) (bool, error)
+}
+
+type ClientRepository interface {
+	// Create adds a client to a queue and sets its ID. Clients without a
+	// status are waiting and clients without a join time joined now. It
+	// returns ErrNotFound when the queue does not exist.
+	Create(ctx context.Context, client *models.Client) error
+	Get(ctx context.Context, id int32) (*models.Client, error)
+	// ListActive returns the clients still occupying a queue.
+	ListActive(ctx context.Context, queueID int32, filter ClientFilter) (*ClientPage, error)
+	CountWaiting(ctx context.Context, queueID int32) (int32, error)
+	CountWaitlisted(ctx context.Context, queueID int32) (int32, error)
+	// CallNext hands the first waiting client of a queue, as ordered by the
+	// queue's ordering policy, to a counter. Two counters calling at the same
+	// time never receive the same client. The first waitlisted client takes
+	// the freed place. It returns ErrQueuePaused while the queue's state
+	// keeps it from calling clients, and ErrCounterClosed or
+	// ErrCounterNotAssigned when the counter cannot call from the queue.
+	// The client is told it was called and the clients moving up that their
+	// turn is near.
+	CallNext(ctx context.Context, queueID, counterID int32, n *notifier.Notifier) (*models.Client, error)
+	// Transition moves a client to next and stamps when it got there. It
+	// returns a *TransitionError when the client's state does not allow it.
+	// Waitlisted clients take the places it frees, and the clients moving up
+	// are told when their turn is near.
+	Transition(ctx context.Context, clientID int32, next models.ClientStatus, n *notifier.Notifier) (*models.Client, error)
+	// ServiceDurations returns how long the last limit services of a queue
+	// took in seconds, newest first.
+	ServiceDurations(ctx context.Context, queueID int32, limit int) ([]float64, error)
+	// OpenCounters counts the open counters assigned to a queue.
+	OpenCounters(ctx context.Context, queueID int32) (int32, error)
+	// Archive moves up to limit clients that finished before the given time
+	// out of the clients table into the archive and returns how many it
+	// moved. Archived clients are no longer returned by Get.
+	Archive(ctx context.Context, finishedBefore time.Time, limit int) (int64, error)
+}
+
+type CounterRepository interface {
+	// Create stores a new counter, assigned to its QueueIDs, and sets its
+	// ID. It returns ErrNotFound when one of the queues does not exist or
+	// is deleted.
+	Create(ctx context.Context, counter *models.Counter) error
+	Get(ctx context.Context, id int32) (*models.Counter, error)
+	// List returns the counters ordered by ID, only the ones assigned to
+	// queueID unless it is zero.
+	List(ctx context.Context, queueID int32) ([]*models.Counter, error)
+	// Assign replaces the queues a counter is assigned to. It returns
+	// ErrNotFound when the counter or one of the queues does not exist, or
+	// the queue is deleted.
+	Assign(ctx context.Context, id int32, queueIDs []int32) error
+	// SetOpen opens or closes a counter.
+	SetOpen(ctx context.Context, id int32, open bool) error
+}
+
+// sortedIDs returns ids sorted and without duplicates.
+func sortedIDs(ids []int32) []int32 {
+	seen := make(map[int32]bool, len(ids))
+	var sorted []int32
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			sorted = append(sorted, id)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
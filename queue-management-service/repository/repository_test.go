@@ -0,0 +1,726 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"queue-management-system/migrate"
+	"queue-management-system/queue-management-service/migrations"
+	"queue-management-system/queue-management-service/models"
+	"queue-management-system/schedule"
+)
+
+// newRepositories returns empty repositories of one backend.
+type newRepositories func(t *testing.T) (QueueRepository, ClientRepository, CounterRepository)
+
+func TestMemoryRepositories(t *testing.T) {
+	testRepositories(t, func(t *testing.T) (QueueRepository, ClientRepository, CounterRepository) {
+		queues := NewMemoryQueueRepository()
+		return queues, NewMemoryClientRepository(queues), NewMemoryCounterRepository(queues)
+	})
+}
+
+func TestPostgresRepositories(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		dsn = "user=postgres password=root dbname=testDB sslmode=disable"
+	}
+	// The tests get a schema of their own so they can run alongside the
+	// server tests sharing the database.
+	db, err := sql.Open("postgres", dsn+" search_path=repository_test")
+	require.NoError(t, err)
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Skipf("test database unavailable: %v", err)
+	}
+	createTestSchema(t, db)
+
+	testRepositories(t, func(t *testing.T) (QueueRepository, ClientRepository, CounterRepository) {
+		_, err := db.Exec("TRUNCATE TABLE clients, clients_archive, queues, ticket_sequences, counters, counter_queues RESTART IDENTITY")
+		require.NoError(t, err)
+		return NewPostgresQueueRepository(db), NewPostgresClientRepository(db), NewPostgresCounterRepository(db)
+	})
+}
+
+// createTestSchema recreates the test schema from the migrations. The queue
+// tables build on the clients table of the client service, so its
+// migrations run first.
+func createTestSchema(t *testing.T, db *sql.DB) {
+	_, err := db.Exec("DROP SCHEMA IF EXISTS repository_test CASCADE; CREATE SCHEMA repository_test")
+	require.NoError(t, err)
+
+	clients, err := migrate.New(db, "client", os.DirFS("../../client-service/migrations"))
+	require.NoError(t, err)
+	require.NoError(t, clients.Up(context.Background()))
+	queues, err := migrate.New(db, "queue-management", migrations.FS)
+	require.NoError(t, err)
+	require.NoError(t, queues.Up(context.Background()))
+}
+
+// testRepositories is the conformance suite every backend has to pass.
+func testRepositories(t *testing.T, newRepos newRepositories) {
+	ctx := context.Background()
+
+	createQueue := func(t *testing.T, queues QueueRepository, name string, policy models.OrderingPolicy) *models.Queue {
+		queue := &models.Queue{
+			Name:           name,
+			OrderingPolicy: policy,
+			AgingSeconds:   60,
+			TicketPadding:  models.DefaultTicketPadding,
+			TicketReset:    models.TicketResetDaily,
+		}
+		require.NoError(t, queues.Create(ctx, queue))
+		return queue
+	}
+	// addClients adds waiting clients to a queue, one minute apart in the
+	// order given.
+	addClients := func(t *testing.T, clients ClientRepository, queueID int32, names ...string) {
+		joined := time.Now().Add(-time.Duration(len(names)) * time.Minute)
+		for i, name := range names {
+			client := &models.Client{QueueID: queueID, Name: name, JoinedAt: joined.Add(time.Duration(i) * time.Minute)}
+			require.NoError(t, clients.Create(ctx, client))
+		}
+	}
+	// openCounter creates an open counter assigned to queueIDs. Counters
+	// are numbered from 1 in each test.
+	openCounter := func(t *testing.T, counters CounterRepository, queueIDs ...int32) *models.Counter {
+		counter := &models.Counter{Name: "Counter", Open: true, QueueIDs: queueIDs}
+		require.NoError(t, counters.Create(ctx, counter))
+		return counter
+	}
+	callAll := func(t *testing.T, clients ClientRepository, queueID int32) []string {
+		var names []string
+		for {
+			client, err := clients.CallNext(ctx, queueID, 1, nil)
+			if err == ErrNotFound {
+				return names
+			}
+			require.NoError(t, err)
+			names = append(names, client.Name)
+		}
+	}
+	names := func(page *ClientPage) []string {
+		var names []string
+		for _, client := range page.Clients {
+			names = append(names, client.Name)
+		}
+		return names
+	}
+
+	t.Run("Queues", func(t *testing.T) {
+		queues, _, _ := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		assert.NotZero(t, queue.ID)
+
+		stored, err := queues.Get(ctx, queue.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, queue, stored)
+
+		prefix := "B"
+		require.NoError(t, queues.Update(ctx, QueueUpdate{ID: queue.ID, Name: "Loans", TicketPrefix: &prefix}))
+		stored, err = queues.Get(ctx, queue.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, "Loans", stored.Name)
+		assert.Equal(t, "B", stored.TicketPrefix)
+		assert.Equal(t, int32(models.DefaultTicketPadding), stored.TicketPadding)
+		assert.Equal(t, models.OrderStrict, stored.OrderingPolicy)
+
+		deleted, err := queues.Delete(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+		_, err = queues.Get(ctx, queue.ID, false)
+		assert.Equal(t, ErrNotFound, err)
+		_, err = queues.Delete(ctx, queue.ID)
+		assert.Equal(t, ErrNotFound, err)
+		assert.Equal(t, ErrNotFound, queues.Update(ctx, QueueUpdate{ID: queue.ID, Name: "Gone"}))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		cash := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		loans := createQueue(t, queues, "Loans", models.OrderStrict)
+		express := createQueue(t, queues, "Express cash", models.OrderStrict)
+		openCounter(t, counters, cash.ID)
+		closed := createQueue(t, queues, "Old cash desk", models.OrderStrict)
+		_, err := queues.Delete(ctx, closed.ID)
+		require.NoError(t, err)
+
+		addClients(t, clients, cash.ID, "a", "b")
+		joined := time.Now().Add(-10 * time.Minute)
+		for _, wait := range []time.Duration{time.Minute, 3 * time.Minute} {
+			called := joined.Add(wait)
+			require.NoError(t, clients.Create(ctx, &models.Client{
+				QueueID: cash.ID, Name: "called", Status: models.StatusCalled, JoinedAt: joined, CalledAt: &called, CounterID: 1,
+			}))
+		}
+
+		ids := func(queues []*QueueSummary) []int32 {
+			var ids []int32
+			for _, q := range queues {
+				ids = append(ids, q.ID)
+			}
+			return ids
+		}
+
+		all, err := queues.List(ctx, QueueFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{cash.ID, loans.ID, express.ID}, ids(all))
+		assert.Equal(t, int32(2), all[0].Waiting)
+		assert.InDelta(t, 120, all[0].AverageWaitSeconds, 1)
+		assert.Zero(t, all[1].Waiting)
+		assert.Zero(t, all[1].AverageWaitSeconds)
+		assert.Equal(t, models.QueueActive, all[0].Status())
+
+		named, err := queues.List(ctx, QueueFilter{NameContains: "CASH", Statuses: []models.QueueStatus{models.QueueActive, models.QueueDeleted}})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{cash.ID, express.ID, closed.ID}, ids(named))
+
+		deleted, err := queues.List(ctx, QueueFilter{Statuses: []models.QueueStatus{models.QueueDeleted}})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{closed.ID}, ids(deleted))
+		assert.Equal(t, models.QueueDeleted, deleted[0].Status())
+
+		page, err := queues.List(ctx, QueueFilter{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{cash.ID, loans.ID}, ids(page))
+		late := createQueue(t, queues, "Late", models.OrderStrict)
+		page, err = queues.List(ctx, QueueFilter{AfterID: loans.ID, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{express.ID, late.ID}, ids(page))
+
+		// Wildcards in the filter match themselves only.
+		discount := createQueue(t, queues, "50% off", models.OrderStrict)
+		createQueue(t, queues, "500 off", models.OrderStrict)
+		underscored := createQueue(t, queues, "desk_a", models.OrderStrict)
+		createQueue(t, queues, "deskba", models.OrderStrict)
+		for filter, want := range map[string]int32{"50%": discount.ID, "k_a": underscored.ID} {
+			named, err = queues.List(ctx, QueueFilter{NameContains: filter})
+			require.NoError(t, err)
+			assert.Equal(t, []int32{want}, ids(named), filter)
+		}
+	})
+
+	t.Run("Schedules", func(t *testing.T) {
+		queues, _, _ := newRepos(t)
+		hours := &schedule.Schedule{
+			TimeZone: "Asia/Almaty",
+			Weekly:   []schedule.Hours{{Day: time.Monday, Open: "09:00", Close: "18:00"}},
+			Holidays: []string{"2024-05-09"},
+		}
+		scheduled := &models.Queue{Name: "Cash desk", OrderingPolicy: models.OrderStrict, TicketPadding: 3,
+			TicketReset: models.TicketResetDaily, Schedule: hours}
+		require.NoError(t, queues.Create(ctx, scheduled))
+		createQueue(t, queues, "Info", models.OrderStrict)
+
+		stored, err := queues.Get(ctx, scheduled.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, hours, stored.Schedule)
+
+		listed, err := queues.ListScheduled(ctx)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, scheduled.ID, listed[0].ID)
+
+		changed, err := queues.SetScheduleClosed(ctx, scheduled.ID, true, nil)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		changed, err = queues.SetScheduleClosed(ctx, scheduled.ID, true, nil)
+		require.NoError(t, err)
+		assert.False(t, changed, "already closed")
+
+		// Replacing the schedule has the scheduler evaluate it afresh.
+		notifying := *hours
+		notifying.NotifyOnClose = true
+		require.NoError(t, queues.Update(ctx, QueueUpdate{ID: scheduled.ID, Name: "Cash desk", Schedule: &notifying}))
+		stored, err = queues.Get(ctx, scheduled.ID, false)
+		require.NoError(t, err)
+		assert.True(t, stored.Schedule.NotifyOnClose)
+		assert.False(t, stored.ScheduleClosed)
+
+		require.NoError(t, queues.Update(ctx, QueueUpdate{ID: scheduled.ID, Name: "Cash desk"}))
+		stored, err = queues.Get(ctx, scheduled.ID, false)
+		require.NoError(t, err)
+		assert.NotNil(t, stored.Schedule, "left unchanged")
+
+		require.NoError(t, queues.Update(ctx, QueueUpdate{ID: scheduled.ID, Name: "Cash desk", ClearSchedule: true}))
+		stored, err = queues.Get(ctx, scheduled.ID, false)
+		require.NoError(t, err)
+		assert.Nil(t, stored.Schedule)
+		listed, err = queues.ListScheduled(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+	})
+
+	t.Run("State", func(t *testing.T) {
+		queues, clients, _ := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		assert.Equal(t, models.QueueOpen, queue.State)
+		addClients(t, clients, queue.ID, "a")
+
+		require.NoError(t, queues.SetState(ctx, queue.ID, models.QueuePaused, nil))
+		stored, err := queues.Get(ctx, queue.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, models.QueuePaused, stored.State)
+		_, err = clients.CallNext(ctx, queue.ID, 1, nil)
+		assert.Equal(t, ErrQueuePaused, err)
+		waiting, err := clients.CountWaiting(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), waiting)
+		listed, err := queues.List(ctx, QueueFilter{})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, models.QueuePaused, listed[0].State)
+
+		assert.Equal(t, ErrNotFound, queues.SetState(ctx, 999, models.QueueClosed, nil))
+		_, err = clients.Transition(ctx, 1, models.StatusCancelled, nil)
+		require.NoError(t, err)
+		_, err = queues.Delete(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ErrNotFound, queues.SetState(ctx, queue.ID, models.QueueOpen, nil), "deleted queues keep their state")
+	})
+
+	t.Run("DeleteQueue", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		openCounter(t, counters, queue.ID)
+		other := createQueue(t, queues, "Loans", models.OrderStrict)
+		addClients(t, clients, queue.ID, "a", "b")
+		addClients(t, clients, other.ID, "c")
+
+		_, err := queues.Delete(ctx, queue.ID)
+		var active *ActiveClientsError
+		require.ErrorAs(t, err, &active)
+		assert.Equal(t, int64(2), active.Count)
+
+		served, err := clients.CallNext(ctx, queue.ID, 1, nil)
+		require.NoError(t, err)
+		for _, next := range []models.ClientStatus{models.StatusServing, models.StatusServed} {
+			_, err = clients.Transition(ctx, served.ID, next, nil)
+			require.NoError(t, err)
+		}
+		_, err = clients.CallNext(ctx, queue.ID, 1, nil)
+		require.NoError(t, err)
+		_, err = queues.Delete(ctx, queue.ID)
+		require.ErrorAs(t, err, &active)
+		assert.Equal(t, int64(1), active.Count, "called clients still occupy the queue")
+
+		cancelled, err := clients.ListActive(ctx, queue.ID, ClientFilter{})
+		require.NoError(t, err)
+		require.Len(t, cancelled.Clients, 1)
+		_, err = clients.Transition(ctx, cancelled.Clients[0].ID, models.StatusCancelled, nil)
+		require.NoError(t, err)
+
+		kept, err := queues.Delete(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), kept)
+		_, err = queues.Get(ctx, queue.ID, false)
+		assert.Equal(t, ErrNotFound, err)
+		assert.Equal(t, ErrNotFound, queues.Update(ctx, QueueUpdate{ID: queue.ID, Name: "Gone"}))
+		_, err = queues.Delete(ctx, queue.ID)
+		assert.Equal(t, ErrNotFound, err)
+
+		// Deleted queues keep their history.
+		stored, err := queues.Get(ctx, queue.ID, true)
+		require.NoError(t, err)
+		assert.NotNil(t, stored.DeletedAt)
+		client, err := clients.Get(ctx, served.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusServed, client.Status)
+
+		remaining, err := clients.ListActive(ctx, other.ID, ClientFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c"}, names(remaining))
+
+		require.NoError(t, queues.Restore(ctx, queue.ID))
+		stored, err = queues.Get(ctx, queue.ID, false)
+		require.NoError(t, err)
+		assert.Nil(t, stored.DeletedAt)
+		assert.Equal(t, ErrNotFound, queues.Restore(ctx, queue.ID), "the queue is not deleted")
+		assert.Equal(t, ErrNotFound, queues.Restore(ctx, 999))
+
+		assert.Equal(t, ErrNotFound, clients.Create(ctx, &models.Client{QueueID: 999, Name: "lost"}))
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		openCounter(t, counters, queue.ID)
+		addClients(t, clients, queue.ID, "a", "b", "c")
+
+		served, err := clients.CallNext(ctx, queue.ID, 1, nil)
+		require.NoError(t, err)
+		for _, next := range []models.ClientStatus{models.StatusServing, models.StatusServed} {
+			_, err = clients.Transition(ctx, served.ID, next, nil)
+			require.NoError(t, err)
+		}
+		_, err = clients.CallNext(ctx, queue.ID, 1, nil)
+		require.NoError(t, err)
+
+		n, err := clients.Archive(ctx, time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Zero(t, n, "nobody finished an hour ago")
+
+		n, err = clients.Archive(ctx, time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n, "only the served client has finished")
+		_, err = clients.Get(ctx, served.ID)
+		assert.Equal(t, ErrNotFound, err)
+		durations, err := clients.ServiceDurations(ctx, queue.ID, 10)
+		require.NoError(t, err)
+		assert.Len(t, durations, 1, "archived services still feed the estimate")
+
+		// A client called within the window still counts once archived.
+		joined := time.Now().Add(-20 * time.Minute)
+		called, finished := joined.Add(10*time.Minute), joined.Add(15*time.Minute)
+		require.NoError(t, clients.Create(ctx, &models.Client{
+			QueueID: queue.ID, Name: "d", Status: models.StatusServed, JoinedAt: joined, CalledAt: &called, ServedAt: &finished,
+		}))
+		before, err := queues.List(ctx, QueueFilter{})
+		require.NoError(t, err)
+		n, err = clients.Archive(ctx, time.Now(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+		after, err := queues.List(ctx, QueueFilter{})
+		require.NoError(t, err)
+		assert.InDelta(t, before[0].AverageWaitSeconds, after[0].AverageWaitSeconds, 1)
+		assert.Greater(t, after[0].AverageWaitSeconds, 100.0)
+
+		active, err := clients.ListActive(ctx, queue.ID, ClientFilter{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"b", "c"}, names(active))
+	})
+
+	t.Run("ClientDefaults", func(t *testing.T) {
+		queues, clients, _ := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+
+		client := &models.Client{QueueID: queue.ID, Name: "Dias Ermek", Email: "dias@example.com", TicketNumber: "001"}
+		require.NoError(t, clients.Create(ctx, client))
+		assert.NotZero(t, client.ID)
+
+		stored, err := clients.Get(ctx, client.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Dias Ermek", stored.Name)
+		assert.Equal(t, "001", stored.TicketNumber)
+		assert.Equal(t, models.StatusWaiting, stored.Status)
+		assert.WithinDuration(t, time.Now(), stored.JoinedAt, time.Minute)
+		assert.Nil(t, stored.CalledAt)
+
+		_, err = clients.Get(ctx, 999)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("ListActive", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		openCounter(t, counters, queue.ID)
+		addClients(t, clients, queue.ID, "Client A", "Client B", "Client C", "Client D")
+		vip := &models.Client{QueueID: queue.ID, Name: "VIP", Priority: 1}
+		require.NoError(t, clients.Create(ctx, vip))
+
+		called, err := clients.CallNext(ctx, queue.ID, 1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "VIP", called.Name)
+		_, err = clients.Transition(ctx, 4, models.StatusCancelled, nil)
+		require.NoError(t, err)
+
+		list, err := clients.ListActive(ctx, queue.ID, ClientFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"VIP", "Client A", "Client B", "Client C"}, names(list))
+		assert.Equal(t, int32(4), list.Total)
+		var places []int32
+		for _, client := range list.Clients {
+			places = append(places, client.Place)
+		}
+		assert.Equal(t, []int32{0, 1, 2, 3}, places, "the called client has no place")
+
+		list, err = clients.ListActive(ctx, queue.ID, ClientFilter{NameContains: "client b"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Client B"}, names(list))
+
+		list, err = clients.ListActive(ctx, queue.ID, ClientFilter{Limit: 2, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Client A", "Client B"}, names(list))
+		assert.Equal(t, int32(4), list.Total, "the total ignores limit and offset")
+
+		list, err = clients.ListActive(ctx, queue.ID, ClientFilter{NameContains: "client", Offset: 10})
+		require.NoError(t, err)
+		assert.Empty(t, list.Clients)
+		assert.Equal(t, int32(3), list.Total)
+
+		list, err = clients.ListActive(ctx, queue.ID, ClientFilter{Sort: []SortKey{{Field: SortName, Descending: true}}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"VIP", "Client C", "Client B", "Client A"}, names(list))
+
+		list, err = clients.ListActive(ctx, queue.ID, ClientFilter{Sort: []SortKey{
+			{Field: SortPriority, Descending: true},
+			{Field: SortJoinedAt, Descending: true},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"VIP", "Client C", "Client B", "Client A"}, names(list))
+
+		list, err = clients.ListActive(ctx, queue.ID, ClientFilter{Sort: []SortKey{{Field: SortPosition, Descending: true}}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Client C", "Client B", "Client A", "VIP"}, names(list))
+
+		_, err = clients.ListActive(ctx, queue.ID, ClientFilter{Sort: []SortKey{{Field: "name; DROP TABLE clients"}}})
+		assert.Equal(t, ErrUnknownSortField, err)
+
+		waiting, err := clients.CountWaiting(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), waiting)
+	})
+
+	t.Run("CallNextStrict", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Strict", models.OrderStrict)
+		openCounter(t, counters, queue.ID)
+		counter := openCounter(t, counters, queue.ID)
+		addClients(t, clients, queue.ID, "A", "B", "C")
+		require.NoError(t, clients.Create(ctx, &models.Client{QueueID: queue.ID, Name: "VIP", Priority: 1}))
+
+		first, err := clients.CallNext(ctx, queue.ID, counter.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "VIP", first.Name)
+		assert.Equal(t, models.StatusCalled, first.Status)
+		assert.Equal(t, counter.ID, first.CounterID)
+		assert.NotNil(t, first.CalledAt)
+
+		assert.Equal(t, []string{"A", "B", "C"}, callAll(t, clients, queue.ID))
+		_, err = clients.CallNext(ctx, queue.ID, counter.ID, nil)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("CallNextCounter", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		other := createQueue(t, queues, "Loans", models.OrderStrict)
+		counter := openCounter(t, counters, queue.ID)
+		elsewhere := openCounter(t, counters, other.ID)
+		addClients(t, clients, queue.ID, "A")
+
+		_, err := clients.CallNext(ctx, queue.ID, elsewhere.ID, nil)
+		assert.Equal(t, ErrCounterNotAssigned, err)
+		_, err = clients.CallNext(ctx, queue.ID, 999, nil)
+		assert.Equal(t, ErrCounterNotAssigned, err)
+		require.NoError(t, counters.SetOpen(ctx, counter.ID, false))
+		_, err = clients.CallNext(ctx, queue.ID, counter.ID, nil)
+		assert.Equal(t, ErrCounterClosed, err)
+
+		require.NoError(t, counters.SetOpen(ctx, counter.ID, true))
+		called, err := clients.CallNext(ctx, queue.ID, counter.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "A", called.Name)
+	})
+
+	t.Run("CallNextWeighted", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Weighted", models.OrderWeighted)
+		openCounter(t, counters, queue.ID)
+		addClients(t, clients, queue.ID, "A", "B", "C")
+		joined := time.Now().Add(-time.Minute)
+		for i, name := range []string{"X", "Y", "Z"} {
+			client := &models.Client{QueueID: queue.ID, Name: name, Priority: 1, JoinedAt: joined.Add(time.Duration(i) * time.Second)}
+			require.NoError(t, clients.Create(ctx, client))
+		}
+
+		// Tier 1 has twice the weight of tier 0, so it gets two of every three calls.
+		assert.Equal(t, []string{"X", "Y", "A", "Z", "B", "C"}, callAll(t, clients, queue.ID))
+	})
+
+	t.Run("CallNextAging", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Aging", models.OrderAging)
+		openCounter(t, counters, queue.ID)
+		require.NoError(t, clients.Create(ctx, &models.Client{QueueID: queue.ID, Name: "Old", JoinedAt: time.Now().Add(-5 * time.Minute)}))
+		require.NoError(t, clients.Create(ctx, &models.Client{QueueID: queue.ID, Name: "VIP", Priority: 2}))
+
+		// Five minutes of waiting promote the regular client above tier 2.
+		assert.Equal(t, []string{"Old", "VIP"}, callAll(t, clients, queue.ID))
+	})
+
+	t.Run("Transition", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		openCounter(t, counters, queue.ID)
+		addClients(t, clients, queue.ID, "A")
+
+		_, err := clients.Transition(ctx, 1, models.StatusServing, nil)
+		var transition *TransitionError
+		require.True(t, errors.As(err, &transition))
+		assert.Equal(t, models.StatusWaiting, transition.From)
+		assert.Equal(t, models.StatusServing, transition.To)
+
+		_, err = clients.CallNext(ctx, queue.ID, 1, nil)
+		require.NoError(t, err)
+		serving, err := clients.Transition(ctx, 1, models.StatusServing, nil)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusServing, serving.Status)
+		assert.NotNil(t, serving.ServingStartedAt)
+
+		served, err := clients.Transition(ctx, 1, models.StatusServed, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, served.ServedAt)
+
+		_, err = clients.Transition(ctx, 999, models.StatusCancelled, nil)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("Waitlist", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := &models.Queue{Name: "Small", OrderingPolicy: models.OrderStrict, TicketPadding: 3,
+			TicketReset: models.TicketResetDaily, MaxSize: 2, OverflowPolicy: models.OverflowWaitlist}
+		require.NoError(t, queues.Create(ctx, queue))
+		openCounter(t, counters, queue.ID)
+		addClients(t, clients, queue.ID, "A", "B")
+		joined := time.Now()
+		for i, name := range []string{"X", "Y", "Z"} {
+			client := &models.Client{QueueID: queue.ID, Name: name, Status: models.StatusWaitlisted,
+				JoinedAt: joined.Add(time.Duration(i) * time.Second)}
+			require.NoError(t, clients.Create(ctx, client))
+		}
+		stored, err := queues.Get(ctx, queue.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), stored.MaxSize)
+		assert.Equal(t, models.OverflowWaitlist, stored.OverflowPolicy)
+
+		waitlisted, err := clients.CountWaitlisted(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), waitlisted)
+		page, err := clients.ListActive(ctx, queue.ID, ClientFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B"}, names(page), "waitlisted clients are not in line")
+
+		// Calling A frees a place for X, cancelling B one for Y.
+		_, err = clients.CallNext(ctx, queue.ID, 1, nil)
+		require.NoError(t, err)
+		waiting, err := clients.CountWaiting(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), waiting)
+		_, err = clients.Transition(ctx, 2, models.StatusCancelled, nil)
+		require.NoError(t, err)
+		page, err = clients.ListActive(ctx, queue.ID, ClientFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "X", "Y"}, names(page))
+
+		// Lifting the limit lets Z in too.
+		unlimited := int32(0)
+		require.NoError(t, queues.Update(ctx, QueueUpdate{ID: queue.ID, Name: "Small", MaxSize: &unlimited}))
+		waitlisted, err = clients.CountWaitlisted(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Zero(t, waitlisted)
+		assert.Equal(t, []string{"X", "Y", "Z"}, callAll(t, clients, queue.ID))
+	})
+
+	t.Run("Throughput", func(t *testing.T) {
+		queues, clients, counters := newRepos(t)
+		queue := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		openCounter(t, counters, queue.ID)
+		second := openCounter(t, counters, queue.ID)
+
+		now := time.Now()
+		served := func(name string, counter int32, calledAgo, startedAgo, servedAgo time.Duration) {
+			calledAt, startedAt, servedAt := now.Add(-calledAgo), now.Add(-startedAgo), now.Add(-servedAgo)
+			require.NoError(t, clients.Create(ctx, &models.Client{
+				QueueID: queue.ID, Name: name, Status: models.StatusServed, CounterID: counter,
+				JoinedAt: now.Add(-3 * time.Hour), CalledAt: &calledAt, ServingStartedAt: &startedAt, ServedAt: &servedAt,
+			}))
+		}
+		served("Older", 1, 2*time.Hour, 2*time.Hour, 119*time.Minute)
+		served("Newer", 2, 5*time.Minute, 4*time.Minute, 2*time.Minute)
+		served("Newest", 2, 3*time.Minute, 2*time.Minute, time.Minute)
+
+		durations, err := clients.ServiceDurations(ctx, queue.ID, 2)
+		require.NoError(t, err)
+		require.Len(t, durations, 2)
+		assert.InDelta(t, 60, durations[0], 1)
+		assert.InDelta(t, 120, durations[1], 1)
+
+		open, err := clients.OpenCounters(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), open)
+
+		// Closed counters and counters of other queues do not serve the queue.
+		require.NoError(t, counters.SetOpen(ctx, second.ID, false))
+		other := createQueue(t, queues, "Loans", models.OrderStrict)
+		openCounter(t, counters, other.ID)
+		open, err = clients.OpenCounters(ctx, queue.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), open)
+	})
+
+	t.Run("Counters", func(t *testing.T) {
+		queues, _, counters := newRepos(t)
+		cash := createQueue(t, queues, "Cash desk", models.OrderStrict)
+		loans := createQueue(t, queues, "Loans", models.OrderStrict)
+
+		counter := &models.Counter{Name: "Window 1", QueueIDs: []int32{loans.ID, cash.ID, loans.ID}}
+		require.NoError(t, counters.Create(ctx, counter))
+		assert.NotZero(t, counter.ID)
+		assert.Equal(t, []int32{cash.ID, loans.ID}, counter.QueueIDs)
+		idle := &models.Counter{Name: "Window 2"}
+		require.NoError(t, counters.Create(ctx, idle))
+
+		stored, err := counters.Get(ctx, counter.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Window 1", stored.Name)
+		assert.False(t, stored.Open)
+		assert.Equal(t, []int32{cash.ID, loans.ID}, stored.QueueIDs)
+		assert.WithinDuration(t, time.Now(), stored.CreatedAt, time.Minute)
+		stored, err = counters.Get(ctx, idle.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.QueueIDs)
+		_, err = counters.Get(ctx, 999)
+		assert.Equal(t, ErrNotFound, err)
+
+		ids := func(counters []*models.Counter) []int32 {
+			var ids []int32
+			for _, c := range counters {
+				ids = append(ids, c.ID)
+			}
+			return ids
+		}
+		all, err := counters.List(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{counter.ID, idle.ID}, ids(all))
+		assigned, err := counters.List(ctx, loans.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{counter.ID}, ids(assigned))
+
+		require.NoError(t, counters.Assign(ctx, counter.ID, []int32{loans.ID}))
+		stored, err = counters.Get(ctx, counter.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{loans.ID}, stored.QueueIDs)
+		assigned, err = counters.List(ctx, cash.ID)
+		require.NoError(t, err)
+		assert.Empty(t, assigned)
+
+		// A failed assignment keeps the queues the counter had.
+		_, err = queues.Delete(ctx, cash.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ErrNotFound, counters.Assign(ctx, counter.ID, []int32{cash.ID}))
+		assert.Equal(t, ErrNotFound, counters.Assign(ctx, counter.ID, []int32{999}))
+		assert.Equal(t, ErrNotFound, counters.Assign(ctx, 999, []int32{loans.ID}))
+		assert.Equal(t, ErrNotFound, counters.Create(ctx, &models.Counter{Name: "Window 3", QueueIDs: []int32{cash.ID}}))
+		stored, err = counters.Get(ctx, counter.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{loans.ID}, stored.QueueIDs)
+
+		require.NoError(t, counters.SetOpen(ctx, counter.ID, true))
+		stored, err = counters.Get(ctx, counter.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.Open)
+		require.NoError(t, counters.SetOpen(ctx, counter.ID, false))
+		stored, err = counters.Get(ctx, counter.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.Open)
+		assert.Equal(t, ErrNotFound, counters.SetOpen(ctx, 999, true))
+	})
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortField is a field list results can be ordered by. Each listing
+// supports the fields its sortColumns map.
+type SortField string
+
+const (
+	SortID           SortField = "id"
+	SortName         SortField = "name"
+	SortEmail        SortField = "email"
+	SortTicketNumber SortField = "ticket_number"
+	SortStatus       SortField = "status"
+	SortPriority     SortField = "priority"
+	SortJoinedAt     SortField = "joined_at"
+	// SortPosition orders clients by their place in the queue, called and
+	// serving clients first.
+	SortPosition SortField = "position"
+)
+
+// SortKey orders a listing by one field. Later keys break the ties of
+// earlier ones.
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// sortColumns maps the fields a listing can be sorted by to the SQL
+// expressions sorting it. Only these expressions ever reach ORDER BY.
+type sortColumns map[SortField]string
+
+// clientSortColumns are the fields ListActive sorts by.
+var clientSortColumns = sortColumns{
+	SortID:           "c.id",
+	SortName:         "c.name",
+	SortEmail:        "c.email",
+	SortTicketNumber: "c.ticket_number",
+	SortStatus:       "c.status",
+	SortPriority:     "c.priority",
+	SortJoinedAt:     "c.created_at",
+	SortPosition:     "COALESCE(p.place, 0)",
+}
+
+// defaultClientSort is the queue order ListActive falls back to.
+var defaultClientSort = []SortKey{{Field: SortPosition}, {Field: SortJoinedAt}}
+
+// queueSortColumns are the fields List sorts queues by. Its pages continue
+// after the last ID, so it only sorts by ID for now.
+var queueSortColumns = sortColumns{
+	SortID: "q.id",
+}
+
+// validate returns ErrUnknownSortField unless every key is supported.
+func (c sortColumns) validate(keys []SortKey) error {
+	for _, key := range keys {
+		if _, ok := c[key.Field]; !ok {
+			return ErrUnknownSortField
+		}
+	}
+	return nil
+}
+
+// orderBy returns the ORDER BY clause for keys, ending with tiebreak, if
+// any, so rows come back in a stable order.
+func (c sortColumns) orderBy(keys []SortKey, tiebreak string) (string, error) {
+	if err := c.validate(keys); err != nil {
+		return "", err
+	}
+	terms := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		order := "ASC"
+		if key.Descending {
+			order = "DESC"
+		}
+		terms = append(terms, fmt.Sprintf("%s %s", c[key.Field], order))
+	}
+	if tiebreak != "" {
+		terms = append(terms, tiebreak)
+	}
+	return " ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// compareBy compares two items by keys, using compare to compare them by a
+// single field.
+func compareBy(keys []SortKey, compare func(field SortField) int) int {
+	for _, key := range keys {
+		if c := compare(key.Field); c != 0 {
+			if key.Descending {
+				return -c
+			}
+			return c
+		}
+	}
+	return 0
+}
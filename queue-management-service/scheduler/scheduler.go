@@ -0,0 +1,82 @@
+// Package scheduler closes and reopens queues as their opening hours
+// dictate. Registrations are checked against the schedule itself; the
+// scheduler records when a queue closes and tells its waiting clients if the
+// schedule asks for it.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"queue-management-system/queue-management-service/notifier"
+	"queue-management-system/queue-management-service/repository"
+)
+
+// DefaultInterval is how often the scheduler checks the schedules, and so
+// how late after closing time waiting clients may be told.
+const DefaultInterval = time.Minute
+
+// Scheduler keeps the schedule_closed state of scheduled queues current.
+type Scheduler struct {
+	queues   repository.QueueRepository
+	notifier *notifier.Notifier
+	Interval time.Duration
+}
+
+// New returns a scheduler telling the waiting clients of closing queues
+// through n when their schedule asks for it.
+func New(queues repository.QueueRepository, n *notifier.Notifier) *Scheduler {
+	return &Scheduler{queues: queues, notifier: n, Interval: DefaultInterval}
+}
+
+// Run checks the schedules every Interval until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Tick(ctx, time.Now()); err != nil {
+			log.Printf("scheduler: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick closes the scheduled queues that are closed at now and reopens the
+// ones that are open. A failing queue does not hold up the others; the
+// first error is returned.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) error {
+	queues, err := s.queues.ListScheduled(ctx)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, queue := range queues {
+		open := queue.IsOpen(now)
+		if open != queue.ScheduleClosed {
+			continue
+		}
+		var n *notifier.Notifier
+		if !open && queue.Schedule.NotifyOnClose {
+			n = s.notifier
+		}
+		changed, err := s.queues.SetScheduleClosed(ctx, queue.ID, !open, n)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if changed && !open {
+			log.Printf("scheduler: closed queue %d", queue.ID)
+		}
+	}
+	return firstErr
+}
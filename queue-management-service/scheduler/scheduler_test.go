@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"queue-management-system/queue-management-service/models"
+	"queue-management-system/queue-management-service/notifier"
+	"queue-management-system/queue-management-service/repository"
+	"queue-management-system/schedule"
+)
+
+// recordingQueues records the queues whose waiting clients were told they
+// closed.
+type recordingQueues struct {
+	repository.QueueRepository
+	notified []int32
+}
+
+func (r *recordingQueues) SetScheduleClosed(ctx context.Context, id int32, closed bool, n *notifier.Notifier) (bool, error) {
+	changed, err := r.QueueRepository.SetScheduleClosed(ctx, id, closed, n)
+	if changed && closed && n != nil {
+		r.notified = append(r.notified, id)
+	}
+	return changed, err
+}
+
+func TestTick(t *testing.T) {
+	ctx := context.Background()
+	queues := &recordingQueues{QueueRepository: repository.NewMemoryQueueRepository()}
+
+	hours := []schedule.Hours{{Day: time.Monday, Open: "09:00", Close: "18:00"}}
+	notifying := &models.Queue{Name: "Cash desk", Schedule: &schedule.Schedule{Weekly: hours, NotifyOnClose: true}}
+	quiet := &models.Queue{Name: "Loans", Schedule: &schedule.Schedule{Weekly: hours}}
+	always := &models.Queue{Name: "Info"}
+	for _, queue := range []*models.Queue{notifying, quiet, always} {
+		require.NoError(t, queues.Create(ctx, queue))
+	}
+
+	closed := func(queue *models.Queue) bool {
+		stored, err := queues.Get(ctx, queue.ID, false)
+		require.NoError(t, err)
+		return stored.ScheduleClosed
+	}
+
+	s := New(queues, notifier.New(notifier.DefaultThreshold, nil))
+	monday := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, s.Tick(ctx, monday.Add(10*time.Hour)))
+	assert.False(t, closed(notifying))
+	assert.Empty(t, queues.notified)
+
+	require.NoError(t, s.Tick(ctx, monday.Add(18*time.Hour)))
+	assert.True(t, closed(notifying))
+	assert.True(t, closed(quiet))
+	assert.False(t, closed(always))
+	assert.Equal(t, []int32{notifying.ID}, queues.notified)
+
+	require.NoError(t, s.Tick(ctx, monday.Add(19*time.Hour)))
+	assert.Equal(t, []int32{notifying.ID}, queues.notified, "clients are told once per closing")
+
+	require.NoError(t, s.Tick(ctx, monday.AddDate(0, 0, 7).Add(9*time.Hour)))
+	assert.False(t, closed(notifying))
+	assert.False(t, closed(quiet))
+}
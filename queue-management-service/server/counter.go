@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+
+	"queue-management-system/queue-management-service/models"
+	"queue-management-system/queue-management-service/pb"
+	"queue-management-system/queue-management-service/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CounterServiceServer struct {
+	pb.UnimplementedCounterServiceServer
+	counters repository.CounterRepository
+}
+
+func NewCounterService(counters repository.CounterRepository) *CounterServiceServer {
+	return &CounterServiceServer{counters: counters}
+}
+
+func (s *CounterServiceServer) CreateCounter(ctx context.Context, req *pb.CreateCounterRequest) (*pb.CreateCounterResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "Counter name is required")
+	}
+
+	counter := &models.Counter{Name: req.Name, Open: req.Open, QueueIDs: req.QueueIds}
+	if err := s.counters.Create(ctx, counter); err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Queue not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateCounterResponse{Success: true, Message: "Counter created successfully", Counter: toPBCounter(counter)}, nil
+}
+
+func (s *CounterServiceServer) GetCounter(ctx context.Context, req *pb.GetCounterRequest) (*pb.GetCounterResponse, error) {
+	counter, err := s.loadCounter(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCounterResponse{Counter: toPBCounter(counter), Message: "Counter retrieved successfully"}, nil
+}
+
+func (s *CounterServiceServer) ListCounters(ctx context.Context, req *pb.ListCountersRequest) (*pb.ListCountersResponse, error) {
+	counters, err := s.counters.List(ctx, req.QueueId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.ListCountersResponse{}
+	for _, counter := range counters {
+		resp.Counters = append(resp.Counters, toPBCounter(counter))
+	}
+	return resp, nil
+}
+
+func (s *CounterServiceServer) AssignCounter(ctx context.Context, req *pb.AssignCounterRequest) (*pb.AssignCounterResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Counter ID is required")
+	}
+	if err := s.counters.Assign(ctx, req.Id, req.QueueIds); err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Counter or queue not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	counter, err := s.loadCounter(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AssignCounterResponse{Success: true, Message: "Counter assigned successfully", Counter: toPBCounter(counter)}, nil
+}
+
+func (s *CounterServiceServer) OpenCounter(ctx context.Context, req *pb.OpenCounterRequest) (*pb.OpenCounterResponse, error) {
+	counter, err := s.setOpen(ctx, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OpenCounterResponse{Success: true, Message: "Counter opened successfully", Counter: toPBCounter(counter)}, nil
+}
+
+// CloseCounter stops a counter from calling clients. Clients it already
+// called stay with it.
+func (s *CounterServiceServer) CloseCounter(ctx context.Context, req *pb.CloseCounterRequest) (*pb.CloseCounterResponse, error) {
+	counter, err := s.setOpen(ctx, req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CloseCounterResponse{Success: true, Message: "Counter closed successfully", Counter: toPBCounter(counter)}, nil
+}
+
+func (s *CounterServiceServer) setOpen(ctx context.Context, id int32, open bool) (*models.Counter, error) {
+	if id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Counter ID is required")
+	}
+	if err := s.counters.SetOpen(ctx, id, open); err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Counter not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return s.loadCounter(ctx, id)
+}
+
+func (s *CounterServiceServer) loadCounter(ctx context.Context, id int32) (*models.Counter, error) {
+	if id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Counter ID is required")
+	}
+	counter, err := s.counters.Get(ctx, id)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Counter not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return counter, nil
+}
+
+func toPBCounter(c *models.Counter) *pb.Counter {
+	return &pb.Counter{
+		Id:        c.ID,
+		Name:      c.Name,
+		Open:      c.Open,
+		QueueIds:  c.QueueIDs,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
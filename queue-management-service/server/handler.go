@@ -2,37 +2,35 @@ package server
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
-	"log"
-	"os"
+	"errors"
+	"math"
+	"queue-management-system/eta"
 	"queue-management-system/queue-management-service/models"
+	"queue-management-system/queue-management-service/notifier"
 	"queue-management-system/queue-management-service/pb"
+	"queue-management-system/queue-management-service/repository"
+	"time"
 
-	_ "github.com/lib/pq"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type QueueManagementServiceServer struct {
 	pb.UnimplementedQueueManagementServiceServer
-	db *sql.DB
+	queues   repository.QueueRepository
+	clients  repository.ClientRepository
+	counters repository.CounterRepository
+	notifier *notifier.Notifier
 }
 
-func NewQueueManagementService(db *sql.DB) *QueueManagementServiceServer {
-	if db == nil {
-		dsn := os.Getenv("DATABASE_URL")
-		if dsn == "" {
-			dsn = "user=postgres password=root dbname=d.abaevDB sslmode=disable"
-		}
-		var err error
-		db, err = sql.Open("postgres", dsn)
-		if err != nil {
-			log.Fatalf("failed to connect to database: %v", err)
-		}
-		return &QueueManagementServiceServer{db: db}
-	}
-	return &QueueManagementServiceServer{db: db}
+// NewQueueManagementService returns a server managing queues and calling
+// their clients. Clients are alerted through n when they are called, move
+// up near the front or the queue they wait in is drained; with a nil n
+// nobody is told.
+func NewQueueManagementService(queues repository.QueueRepository, clients repository.ClientRepository, counters repository.CounterRepository,
+	n *notifier.Notifier) *QueueManagementServiceServer {
+	return &QueueManagementServiceServer{queues: queues, clients: clients, counters: counters, notifier: n}
 }
 
 func (s *QueueManagementServiceServer) CreateQueue(ctx context.Context, req *pb.CreateQueueRequest) (*pb.CreateQueueResponse, error) {
@@ -40,8 +38,57 @@ func (s *QueueManagementServiceServer) CreateQueue(ctx context.Context, req *pb.
 		return nil, status.Error(codes.InvalidArgument, "Queue name is required")
 	}
 
-	_, err := s.db.Exec("INSERT INTO queues (name) VALUES ($1)", req.Name)
-	if err != nil {
+	policy := models.OrderingPolicy(req.OrderingPolicy)
+	if policy == "" {
+		policy = models.OrderStrict
+	}
+	if !policy.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "Unknown ordering policy")
+	}
+	agingSeconds := req.AgingSeconds
+	if agingSeconds == 0 {
+		agingSeconds = models.DefaultAgingSeconds
+	}
+	if agingSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Aging seconds must be positive")
+	}
+	padding := req.TicketPadding
+	if padding == 0 {
+		padding = models.DefaultTicketPadding
+	}
+	reset := models.TicketReset(req.TicketReset)
+	if reset == "" {
+		reset = models.TicketResetDaily
+	}
+	if err := validateTicketFormat(req.TicketPrefix, padding, reset); err != nil {
+		return nil, err
+	}
+	queue := &models.Queue{
+		Name:           req.Name,
+		OrderingPolicy: policy,
+		AgingSeconds:   agingSeconds,
+		TicketPrefix:   req.TicketPrefix,
+		TicketPadding:  padding,
+		TicketReset:    reset,
+	}
+	if req.Schedule != nil {
+		hours, err := fromPBSchedule(req.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		queue.Schedule = hours
+	}
+	queue.MaxSize = req.MaxSize
+	queue.OverflowPolicy = models.OverflowPolicy(req.OverflowPolicy)
+	if queue.OverflowPolicy == "" {
+		queue.OverflowPolicy = models.OverflowReject
+	}
+	queue.OverflowQueueID = req.OverflowQueueId
+	if err := s.validateCapacity(ctx, queue); err != nil {
+		return nil, err
+	}
+
+	if err := s.queues.Create(ctx, queue); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.CreateQueueResponse{Success: true, Message: "Queue created successfully"}, nil
@@ -52,23 +99,211 @@ func (s *QueueManagementServiceServer) UpdateQueue(ctx context.Context, req *pb.
 		return nil, status.Error(codes.InvalidArgument, "Queue ID and name are required")
 	}
 
-	_, err := s.db.Exec("UPDATE queues SET name = $1 WHERE id = $2", req.Name, req.Id)
-	if err != nil {
+	update := repository.QueueUpdate{ID: req.Id, Name: req.Name}
+	if req.OrderingPolicy != nil {
+		policy := models.OrderingPolicy(*req.OrderingPolicy)
+		if !policy.Valid() {
+			return nil, status.Error(codes.InvalidArgument, "Unknown ordering policy")
+		}
+		update.OrderingPolicy = &policy
+	}
+	if req.AgingSeconds != nil {
+		if *req.AgingSeconds <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "Aging seconds must be positive")
+		}
+		update.AgingSeconds = req.AgingSeconds
+	}
+	if req.TicketPrefix != nil {
+		if err := validateTicketFormat(*req.TicketPrefix, models.DefaultTicketPadding, models.TicketResetDaily); err != nil {
+			return nil, err
+		}
+		update.TicketPrefix = req.TicketPrefix
+	}
+	if req.TicketPadding != nil {
+		if err := validateTicketFormat("", *req.TicketPadding, models.TicketResetDaily); err != nil {
+			return nil, err
+		}
+		update.TicketPadding = req.TicketPadding
+	}
+	if req.TicketReset != nil {
+		reset := models.TicketReset(*req.TicketReset)
+		if err := validateTicketFormat("", models.DefaultTicketPadding, reset); err != nil {
+			return nil, err
+		}
+		update.TicketReset = &reset
+	}
+	if req.Schedule != nil && req.ClearSchedule {
+		return nil, status.Error(codes.InvalidArgument, "Schedule cannot be both set and cleared")
+	}
+	if req.Schedule != nil {
+		hours, err := fromPBSchedule(req.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		update.Schedule = hours
+	}
+	update.ClearSchedule = req.ClearSchedule
+	if req.MaxSize != nil || req.OverflowPolicy != nil || req.OverflowQueueId != nil {
+		// The capacity settings are checked together, as they will be stored.
+		queue, err := s.queues.Get(ctx, req.Id, false)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				return nil, status.Error(codes.NotFound, "Queue not found")
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if req.MaxSize != nil {
+			queue.MaxSize = *req.MaxSize
+		}
+		if req.OverflowPolicy != nil {
+			queue.OverflowPolicy = models.OverflowPolicy(*req.OverflowPolicy)
+		}
+		if req.OverflowQueueId != nil {
+			queue.OverflowQueueID = *req.OverflowQueueId
+		}
+		if err := s.validateCapacity(ctx, queue); err != nil {
+			return nil, err
+		}
+		update.MaxSize = req.MaxSize
+		if req.OverflowPolicy != nil {
+			update.OverflowPolicy = &queue.OverflowPolicy
+		}
+		update.OverflowQueueID = req.OverflowQueueId
+	}
+
+	if err := s.queues.Update(ctx, update); err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Queue not found")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.UpdateQueueResponse{Success: true, Message: "Queue updated successfully"}, nil
 }
 
+// validateTicketFormat checks the ticket numbering settings of a queue.
+func validateTicketFormat(prefix string, padding int32, reset models.TicketReset) error {
+	if len(prefix) > models.MaxTicketPrefixLength {
+		return status.Errorf(codes.InvalidArgument, "Ticket prefix must be at most %d characters", models.MaxTicketPrefixLength)
+	}
+	if padding < 1 || padding > models.MaxTicketPadding {
+		return status.Errorf(codes.InvalidArgument, "Ticket padding must be between 1 and %d", models.MaxTicketPadding)
+	}
+	if !reset.Valid() {
+		return status.Error(codes.InvalidArgument, "Unknown ticket reset schedule")
+	}
+	return nil
+}
+
+// validateCapacity checks the size limit and overflow settings of a queue.
+// A redirecting queue needs another, existing queue to overflow into.
+func (s *QueueManagementServiceServer) validateCapacity(ctx context.Context, queue *models.Queue) error {
+	if queue.MaxSize < 0 {
+		return status.Error(codes.InvalidArgument, "Max size must not be negative")
+	}
+	if !queue.OverflowPolicy.Valid() {
+		return status.Error(codes.InvalidArgument, "Unknown overflow policy")
+	}
+	if queue.OverflowPolicy == models.OverflowRedirect && queue.OverflowQueueID == 0 {
+		return status.Error(codes.InvalidArgument, "Redirecting queues need an overflow queue")
+	}
+	if queue.OverflowQueueID == 0 {
+		return nil
+	}
+	if queue.OverflowQueueID == queue.ID {
+		return status.Error(codes.InvalidArgument, "Queue cannot overflow into itself")
+	}
+	if _, err := s.queues.Get(ctx, queue.OverflowQueueID, false); err != nil {
+		if err == repository.ErrNotFound {
+			return status.Error(codes.InvalidArgument, "Overflow queue not found")
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
 func (s *QueueManagementServiceServer) DeleteQueue(ctx context.Context, req *pb.DeleteQueueRequest) (*pb.DeleteQueueResponse, error) {
 	if req.Id == 0 {
 		return nil, status.Error(codes.InvalidArgument, "Queue ID is required")
 	}
 
-	_, err := s.db.Exec("DELETE FROM queues WHERE id = $1", req.Id)
+	deleted, err := s.queues.Delete(ctx, req.Id)
 	if err != nil {
+		var active *repository.ActiveClientsError
+		switch {
+		case err == repository.ErrNotFound:
+			return nil, status.Error(codes.NotFound, "Queue not found")
+		case errors.As(err, &active):
+			return nil, status.Errorf(codes.FailedPrecondition, "Queue still has %d active clients", active.Count)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DeleteQueueResponse{
+		Success:         true,
+		Message:         "Queue deleted successfully",
+		AffectedClients: int32(deleted),
+	}, nil
+}
+
+func (s *QueueManagementServiceServer) RestoreQueue(ctx context.Context, req *pb.RestoreQueueRequest) (*pb.RestoreQueueResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Queue ID is required")
+	}
+
+	if err := s.queues.Restore(ctx, req.Id); err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Deleted queue not found")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.DeleteQueueResponse{Success: true, Message: "Queue deleted successfully"}, nil
+	return &pb.RestoreQueueResponse{Success: true, Message: "Queue restored successfully"}, nil
+}
+
+func (s *QueueManagementServiceServer) PauseQueue(ctx context.Context, req *pb.PauseQueueRequest) (*pb.PauseQueueResponse, error) {
+	if err := s.setState(ctx, req.Id, models.QueuePaused, nil); err != nil {
+		return nil, err
+	}
+	return &pb.PauseQueueResponse{Success: true, Message: "Queue paused successfully", State: string(models.QueuePaused)}, nil
+}
+
+func (s *QueueManagementServiceServer) CloseQueue(ctx context.Context, req *pb.CloseQueueRequest) (*pb.CloseQueueResponse, error) {
+	if err := s.setState(ctx, req.Id, models.QueueClosed, nil); err != nil {
+		return nil, err
+	}
+	return &pb.CloseQueueResponse{Success: true, Message: "Queue closed successfully", State: string(models.QueueClosed)}, nil
+}
+
+// DrainQueue closes a queue and tells its waiting clients that it takes no
+// new clients. The queue only drains if they can be told. A client is told
+// once a day about drains: draining again the same day tells nobody new,
+// while a client still waiting on a later day, or when the schedule closes
+// the queue, is told again.
+func (s *QueueManagementServiceServer) DrainQueue(ctx context.Context, req *pb.DrainQueueRequest) (*pb.DrainQueueResponse, error) {
+	if err := s.setState(ctx, req.Id, models.QueueDraining, s.notifier); err != nil {
+		return nil, err
+	}
+	return &pb.DrainQueueResponse{Success: true, Message: "Queue drained successfully", State: string(models.QueueDraining)}, nil
+}
+
+func (s *QueueManagementServiceServer) ReopenQueue(ctx context.Context, req *pb.ReopenQueueRequest) (*pb.ReopenQueueResponse, error) {
+	if err := s.setState(ctx, req.Id, models.QueueOpen, nil); err != nil {
+		return nil, err
+	}
+	return &pb.ReopenQueueResponse{Success: true, Message: "Queue reopened successfully", State: string(models.QueueOpen)}, nil
+}
+
+// setState moves a live queue to state, telling its waiting clients through
+// n that it closed.
+func (s *QueueManagementServiceServer) setState(ctx context.Context, id int32, state models.QueueState, n *notifier.Notifier) error {
+	if id == 0 {
+		return status.Error(codes.InvalidArgument, "Queue ID is required")
+	}
+	if err := s.queues.SetState(ctx, id, state, n); err != nil {
+		if err == repository.ErrNotFound {
+			return status.Error(codes.NotFound, "Queue not found")
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
 }
 
 func (s *QueueManagementServiceServer) GetQueueStatus(ctx context.Context, req *pb.GetQueueStatusRequest) (*pb.GetQueueStatusResponse, error) {
@@ -76,61 +311,269 @@ func (s *QueueManagementServiceServer) GetQueueStatus(ctx context.Context, req *
 		return nil, status.Error(codes.InvalidArgument, "Queue ID is required")
 	}
 
-	row := s.db.QueryRow("SELECT id, name FROM queues WHERE id = $1", req.Id)
-	var queue models.Queue
-	if err := row.Scan(&queue.ID, &queue.Name); err != nil {
-		if err == sql.ErrNoRows {
+	queue, err := s.queues.Get(ctx, req.Id, req.IncludeDeleted)
+	if err != nil {
+		if err == repository.ErrNotFound {
 			return nil, status.Error(codes.NotFound, "Queue not found")
 		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	query := "SELECT name FROM clients WHERE queue_id = $1"
-	args := []interface{}{req.Id}
+	sort, err := clientSort(req)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.clients.ListActive(ctx, req.Id, repository.ClientFilter{
+		NameContains: req.ClientNameFilter,
+		Sort:         sort,
+		Limit:        req.Limit,
+		Offset:       req.Offset,
+	})
+	if err != nil {
+		if err == repository.ErrUnknownSortField {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown sort field %q", req.SortBy)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var clients []string
+	var entries []*pb.QueueEntry
+	for _, client := range page.Clients {
+		clients = append(clients, client.Name)
+		entries = append(entries, &pb.QueueEntry{
+			Id:           client.ID,
+			Name:         client.Name,
+			TicketNumber: client.TicketNumber,
+			Position:     client.Place,
+			JoinedAt:     timestamppb.New(client.JoinedAt),
+			Status:       string(client.Status),
+			Priority:     client.Priority,
+			CounterId:    client.CounterID,
+		})
+	}
 
-	paramIndex := 2
+	waiting, err := s.clients.CountWaiting(ctx, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	waitlisted, err := s.clients.CountWaitlisted(ctx, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	throughput, err := eta.Load(ctx, s.clients, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 
-	if req.ClientNameFilter != "" {
-		query += fmt.Sprintf(" AND name ILIKE $%d", paramIndex)
-		args = append(args, "%"+req.ClientNameFilter+"%")
-		paramIndex++
+	resp := &pb.GetQueueStatusResponse{
+		Id:                   queue.ID,
+		Name:                 queue.Name,
+		Clients:              clients,
+		Entries:              entries,
+		TotalCount:           page.Total,
+		Message:              "Queue status retrieved successfully",
+		EstimatedWaitSeconds: throughput.Wait(waiting),
+		Schedule:             toPBSchedule(queue.Schedule),
+		MaxSize:              queue.MaxSize,
+		OverflowPolicy:       string(queue.OverflowPolicy),
+		WaitlistedCount:      waitlisted,
+		State:                string(queue.State),
+		OpenCounters:         throughput.OpenCounters,
+	}
+	if queue.MaxSize > 0 {
+		resp.FillRatio = float64(waiting) / float64(queue.MaxSize)
+	}
+	if queue.DeletedAt != nil {
+		resp.DeletedAt = timestamppb.New(*queue.DeletedAt)
 	}
+	now := time.Now()
+	resp.Open = queue.State.AcceptsClients() && queue.IsOpen(now)
+	if queue.State.AcceptsClients() && !resp.Open {
+		if next, ok := queue.Schedule.NextOpening(now); ok {
+			resp.NextOpening = timestamppb.New(next)
+		}
+	}
+	return resp, nil
+}
 
-	if req.SortBy != "" {
-		order := "ASC"
-		if req.SortOrder == "desc" {
-			order = "DESC"
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+func (s *QueueManagementServiceServer) ListQueues(ctx context.Context, req *pb.ListQueuesRequest) (*pb.ListQueuesResponse, error) {
+	if req.PageSize < 0 || req.PageSize > maxPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Page size must be between 0 and %d", maxPageSize)
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	afterID, ok := decodePageToken("queues", req.PageToken)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+	}
+	filter := repository.QueueFilter{NameContains: req.NameFilter, AfterID: afterID, Limit: pageSize + 1}
+	for _, st := range req.Statuses {
+		queueStatus := models.QueueStatus(st)
+		if !queueStatus.Valid() {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown queue status %q", st)
 		}
-		query += fmt.Sprintf(" ORDER BY %s %s", req.SortBy, order)
-	} else {
-		query += " ORDER BY name ASC"
+		filter.Statuses = append(filter.Statuses, queueStatus)
 	}
 
-	if req.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", paramIndex)
-		args = append(args, req.Limit)
-		paramIndex++
+	queues, err := s.queues.List(ctx, filter)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// One queue more than a page is read to tell whether another page follows.
+	resp := &pb.ListQueuesResponse{}
+	if len(queues) > int(pageSize) {
+		queues = queues[:pageSize]
+		resp.NextPageToken = encodePageToken("queues", queues[pageSize-1].ID)
+	}
+	for _, q := range queues {
+		resp.Queues = append(resp.Queues, &pb.QueueSummary{
+			Id:                 q.ID,
+			Name:               q.Name,
+			Status:             string(q.Status()),
+			State:              string(q.State),
+			WaitingCount:       q.Waiting,
+			AverageWaitSeconds: int32(math.Round(q.AverageWaitSeconds)),
+		})
+	}
+	return resp, nil
+}
+
+// CallNext hands the first waiting client of a queue, as ordered by the
+// queue's ordering policy, to the calling counter. The counter has to be open
+// and assigned to the queue.
+func (s *QueueManagementServiceServer) CallNext(ctx context.Context, req *pb.CallNextRequest) (*pb.CallNextResponse, error) {
+	if req.QueueId == 0 || req.CounterId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Queue ID and counter ID are required")
 	}
 
-	if req.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", paramIndex)
-		args = append(args, req.Offset)
-		paramIndex++
+	queue, err := s.queues.Get(ctx, req.QueueId, false)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Queue not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !queue.State.CallsClients() {
+		return nil, status.Error(codes.FailedPrecondition, "Queue is paused")
+	}
+	counter, err := s.counters.Get(ctx, req.CounterId)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "Counter not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !counter.Open {
+		return nil, status.Error(codes.FailedPrecondition, "Counter is closed")
+	}
+	if !counter.Serves(req.QueueId) {
+		return nil, status.Error(codes.FailedPrecondition, "Counter is not assigned to the queue")
 	}
 
-	rows, err := s.db.Query(query, args...)
+	client, err := s.clients.CallNext(ctx, req.QueueId, req.CounterId, s.notifier)
 	if err != nil {
+		switch {
+		case err == repository.ErrNotFound:
+			return nil, status.Error(codes.NotFound, "No clients waiting in queue")
+		// The queue or the counter changed since they were read above.
+		case err == repository.ErrQueuePaused:
+			return nil, status.Error(codes.FailedPrecondition, "Queue is paused")
+		case err == repository.ErrCounterClosed:
+			return nil, status.Error(codes.FailedPrecondition, "Counter is closed")
+		case err == repository.ErrCounterNotAssigned:
+			return nil, status.Error(codes.FailedPrecondition, "Counter is not assigned to the queue")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	defer rows.Close()
 
-	var clients []string
-	for rows.Next() {
-		var clientName string
-		if err := rows.Scan(&clientName); err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+	return &pb.CallNextResponse{Client: toPBClient(client), Message: "Client called successfully"}, nil
+}
+
+func (s *QueueManagementServiceServer) StartService(ctx context.Context, req *pb.StartServiceRequest) (*pb.StartServiceResponse, error) {
+	client, err := s.transitionClient(ctx, req.ClientId, models.StatusServing)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StartServiceResponse{Client: toPBClient(client), Message: "Service started successfully"}, nil
+}
+
+func (s *QueueManagementServiceServer) CompleteService(ctx context.Context, req *pb.CompleteServiceRequest) (*pb.CompleteServiceResponse, error) {
+	client, err := s.transitionClient(ctx, req.ClientId, models.StatusServed)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CompleteServiceResponse{Client: toPBClient(client), Message: "Service completed successfully"}, nil
+}
+
+func (s *QueueManagementServiceServer) MarkNoShow(ctx context.Context, req *pb.MarkNoShowRequest) (*pb.MarkNoShowResponse, error) {
+	client, err := s.transitionClient(ctx, req.ClientId, models.StatusNoShow)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MarkNoShowResponse{Client: toPBClient(client), Message: "Client marked as no-show"}, nil
+}
+
+func (s *QueueManagementServiceServer) CancelTicket(ctx context.Context, req *pb.CancelTicketRequest) (*pb.CancelTicketResponse, error) {
+	client, err := s.transitionClient(ctx, req.ClientId, models.StatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CancelTicketResponse{Client: toPBClient(client), Message: "Ticket cancelled successfully"}, nil
+}
+
+// transitionClient moves a client to next.
+func (s *QueueManagementServiceServer) transitionClient(ctx context.Context, clientID int32, next models.ClientStatus) (*models.Client, error) {
+	if clientID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Client ID is required")
+	}
+
+	client, err := s.clients.Transition(ctx, clientID, next, s.notifier)
+	if err != nil {
+		var transition *repository.TransitionError
+		switch {
+		case err == repository.ErrNotFound:
+			return nil, status.Error(codes.NotFound, "Client not found")
+		case errors.As(err, &transition):
+			return nil, status.Errorf(codes.FailedPrecondition, "Cannot move client from %s to %s", transition.From, transition.To)
 		}
-		clients = append(clients, clientName)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return client, nil
+}
+
+func toPBClient(c *models.Client) *pb.Client {
+	client := &pb.Client{
+		Id:           c.ID,
+		Name:         c.Name,
+		QueueId:      c.QueueID,
+		Email:        c.Email,
+		TicketNumber: c.TicketNumber,
+		Status:       string(c.Status),
+		Priority:     c.Priority,
+		JoinedAt:     timestamppb.New(c.JoinedAt),
+		CounterId:    c.CounterID,
+	}
+	if c.CalledAt != nil {
+		client.CalledAt = timestamppb.New(*c.CalledAt)
+	}
+	if c.ServingStartedAt != nil {
+		client.ServingStartedAt = timestamppb.New(*c.ServingStartedAt)
+	}
+	if c.ServedAt != nil {
+		client.ServedAt = timestamppb.New(*c.ServedAt)
+	}
+	if c.NoShowAt != nil {
+		client.NoShowAt = timestamppb.New(*c.NoShowAt)
+	}
+	if c.CancelledAt != nil {
+		client.CancelledAt = timestamppb.New(*c.CancelledAt)
 	}
-	return &pb.GetQueueStatusResponse{Id: queue.ID, Name: queue.Name, Clients: clients, Message: "Queue status retrieved successfully"}, nil
+	return client
 }
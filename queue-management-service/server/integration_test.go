@@ -11,42 +11,25 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"queue-management-system/queue-management-service/pb"
+	"queue-management-system/queue-management-service/repository"
 	"queue-management-system/queue-management-service/server"
 )
 
 var db *sql.DB
 
+// init connects to the schema TestMain migrates.
 func init() {
 	var err error
-	dsn := "user=postgres password=root dbname=testDB sslmode=disable"
+	dsn := "user=postgres password=root dbname=testDB sslmode=disable search_path=server_test"
 	db, err = sql.Open("postgres", dsn)
 	if err != nil {
 		log.Fatalf("failed to connect to test database: %v", err)
 	}
-
-	// Clean up test database before running tests
-	_, err = db.Exec(`DROP TABLE IF EXISTS queues, clients`)
-	if err != nil {
-		log.Fatalf("failed to clean test database: %v", err)
-	}
-	_, err = db.Exec(`
-		CREATE TABLE queues (
-			id SERIAL PRIMARY KEY,
-			name TEXT NOT NULL
-		);
-		CREATE TABLE clients (
-			id SERIAL PRIMARY KEY,
-			name TEXT NOT NULL,
-			queue_id INT REFERENCES queues(id)
-		);
-	`)
-	if err != nil {
-		log.Fatalf("failed to set up test database: %v", err)
-	}
 }
 
 func setupServer() *server.QueueManagementServiceServer {
-	return server.NewQueueManagementService(db)
+	return server.NewQueueManagementService(repository.NewPostgresQueueRepository(db), repository.NewPostgresClientRepository(db),
+		repository.NewPostgresCounterRepository(db), nil)
 }
 
 func TestCreateQueue(t *testing.T) {
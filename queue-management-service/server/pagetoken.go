@@ -0,0 +1,36 @@
+package server
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+// Page tokens are opaque to callers. They carry the listing they belong to
+// and the ID of the last item returned, so a token cannot be replayed
+// against another listing.
+
+func encodePageToken(listing string, lastID int32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(listing + ":" + strconv.Itoa(int(lastID))))
+}
+
+// decodePageToken returns the ID a page continues after, zero for the first
+// page, and false when token was not issued for listing.
+func decodePageToken(listing, token string) (int32, bool) {
+	if token == "" {
+		return 0, true
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, false
+	}
+	name, id, ok := strings.Cut(string(raw), ":")
+	if !ok || name != listing {
+		return 0, false
+	}
+	lastID, err := strconv.ParseInt(id, 10, 32)
+	if err != nil || lastID <= 0 {
+		return 0, false
+	}
+	return int32(lastID), true
+}
@@ -3,26 +3,35 @@ package server
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	notificationpb "notification-service/pb"
 
+	"queue-management-system/eta"
+	"queue-management-system/migrate"
+	"queue-management-system/queue-management-service/migrations"
+	"queue-management-system/queue-management-service/notifier"
 	"queue-management-system/queue-management-service/pb"
+	"queue-management-system/queue-management-service/repository"
 )
 
 var testDB *sql.DB
 
 func TestMain(m *testing.M) {
 
-	dsn := "user=postgres password=root dbname=testDB sslmode=disable"
 	var err error
-	testDB, err = sql.Open("postgres", dsn)
+	testDB, err = sql.Open("postgres", testDSN)
 	if err != nil {
 		log.Fatalf("failed to connect to test database: %v", err)
 	}
@@ -36,33 +45,60 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// testDSN points the tests at a schema of their own, recreated from the
+// migrations on every run.
+const testDSN = "user=postgres password=root dbname=testDB sslmode=disable search_path=server_test"
+
 func setupTestTables() {
-	_, err := testDB.Exec(`
-	CREATE TABLE IF NOT EXISTS queues (
-		id SERIAL PRIMARY KEY,
-		name TEXT NOT NULL
-	);
-	CREATE TABLE IF NOT EXISTS clients (
-		id SERIAL PRIMARY KEY,
-		name TEXT NOT NULL,
-		queue_id INTEGER REFERENCES queues(id)
-	);
-	`)
+	_, err := testDB.Exec("DROP SCHEMA IF EXISTS server_test CASCADE; CREATE SCHEMA server_test")
 	if err != nil {
-		log.Fatalf("failed to create test database tables: %v", err)
+		log.Fatalf("failed to create test schema: %v", err)
+	}
+
+	// The queue tables build on the clients table of the client service.
+	for _, service := range []struct {
+		name string
+		fsys fs.FS
+	}{
+		{"client", os.DirFS("../../client-service/migrations")},
+		{"queue-management", migrations.FS},
+	} {
+		m, err := migrate.New(testDB, service.name, service.fsys)
+		if err != nil {
+			log.Fatalf("failed to load %s migrations: %v", service.name, err)
+		}
+		if err := m.Up(context.Background()); err != nil {
+			log.Fatalf("failed to migrate test database: %v", err)
+		}
 	}
 }
 
 func setupTestDB() {
-	_, err := testDB.Exec("TRUNCATE TABLE clients, queues RESTART IDENTITY")
+	_, err := testDB.Exec("TRUNCATE TABLE clients, clients_archive, queues, ticket_sequences, notification_outbox, counters, counter_queues RESTART IDENTITY")
 	if err != nil {
 		log.Fatalf("failed to truncate test database tables: %v", err)
 	}
 }
 
+// newTestServer returns a server backed by the test database.
+func newTestServer() *QueueManagementServiceServer {
+	return NewQueueManagementService(repository.NewPostgresQueueRepository(testDB), repository.NewPostgresClientRepository(testDB),
+		repository.NewPostgresCounterRepository(testDB), nil)
+}
+
+// openCounter opens the counter with the given ID, creating it when needed,
+// and assigns it to a queue.
+func openCounter(t *testing.T, id, queueID int32) {
+	_, err := testDB.Exec(`INSERT INTO counters (id, name, open) VALUES ($1, $2, TRUE)
+		ON CONFLICT (id) DO UPDATE SET open = TRUE`, id, fmt.Sprintf("Counter %d", id))
+	require.NoError(t, err)
+	_, err = testDB.Exec("INSERT INTO counter_queues (counter_id, queue_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", id, queueID)
+	require.NoError(t, err)
+}
+
 func TestCreateQueue(t *testing.T) {
 	setupTestDB()
-	server := NewQueueManagementService(testDB)
+	server := newTestServer()
 
 	t.Run("Success", func(t *testing.T) {
 		req := &pb.CreateQueueRequest{Name: "Test Queue"}
@@ -79,11 +115,234 @@ func TestCreateQueue(t *testing.T) {
 		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		assert.Equal(t, "Queue name is required", status.Convert(err).Message())
 	})
+
+	t.Run("TicketFormat", func(t *testing.T) {
+		req := &pb.CreateQueueRequest{Name: "Tickets", TicketPrefix: "A", TicketPadding: 4, TicketReset: "never"}
+		_, err := server.CreateQueue(context.Background(), req)
+		require.NoError(t, err)
+
+		var prefix, reset string
+		var padding int32
+		err = testDB.QueryRow("SELECT ticket_prefix, ticket_padding, ticket_reset FROM queues WHERE name = 'Tickets'").
+			Scan(&prefix, &padding, &reset)
+		require.NoError(t, err)
+		assert.Equal(t, "A", prefix)
+		assert.Equal(t, int32(4), padding)
+		assert.Equal(t, "never", reset)
+	})
+
+	t.Run("InvalidTicketFormat", func(t *testing.T) {
+		_, err := server.CreateQueue(context.Background(), &pb.CreateQueueRequest{Name: "Bad", TicketReset: "weekly"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "Unknown ticket reset schedule", status.Convert(err).Message())
+
+		_, err = server.CreateQueue(context.Background(), &pb.CreateQueueRequest{Name: "Bad", TicketPadding: 12})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestQueueSchedule(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+	ctx := context.Background()
+
+	// Open for one minute a week, so the queue is closed while the test runs
+	// unless it runs in that minute.
+	opening := time.Now().UTC().Add(time.Hour)
+	weekly := []*pb.OpeningHours{{
+		Day:   int32(opening.Weekday()),
+		Open:  opening.Format("15:04"),
+		Close: opening.Add(time.Minute).Format("15:04"),
+	}}
+	if weekly[0].Close < weekly[0].Open {
+		weekly[0].Close = "24:00"
+	}
+	_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Scheduled", Schedule: &pb.Schedule{Weekly: weekly}})
+	require.NoError(t, err)
+
+	resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+	require.NoError(t, err)
+	assert.False(t, resp.Open)
+	require.NotNil(t, resp.NextOpening)
+	assert.Equal(t, opening.Truncate(time.Minute), resp.NextOpening.AsTime())
+	assert.Equal(t, weekly[0].Open, resp.Schedule.Weekly[0].Open)
+
+	_, err = server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Bad", Schedule: &pb.Schedule{TimeZone: "Mars/Olympus"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = server.UpdateQueue(ctx, &pb.UpdateQueueRequest{Id: 1, Name: "Scheduled", Schedule: &pb.Schedule{
+		Weekly: []*pb.OpeningHours{{Day: 1, Open: "18:00", Close: "09:00"}},
+	}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.UpdateQueue(ctx, &pb.UpdateQueueRequest{Id: 1, Name: "Scheduled", ClearSchedule: true})
+	require.NoError(t, err)
+	resp, err = server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+	require.NoError(t, err)
+	assert.True(t, resp.Open)
+	assert.Nil(t, resp.NextOpening)
+	assert.Nil(t, resp.Schedule)
+}
+
+func TestQueueCapacity(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+	ctx := context.Background()
+
+	_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Small", MaxSize: 4, OverflowPolicy: "waitlist"})
+	require.NoError(t, err)
+	_, err = testDB.Exec("INSERT INTO clients (name, queue_id, status) VALUES ('A', 1, 'waiting'), ('B', 1, 'waiting'), ('C', 1, 'waitlisted')")
+	require.NoError(t, err)
+
+	resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), resp.MaxSize)
+	assert.Equal(t, "waitlist", resp.OverflowPolicy)
+	assert.Equal(t, 0.5, resp.FillRatio)
+	assert.Equal(t, int32(1), resp.WaitlistedCount)
+	assert.Equal(t, []string{"A", "B"}, resp.Clients)
+
+	t.Run("InvalidSettings", func(t *testing.T) {
+		for name, req := range map[string]*pb.CreateQueueRequest{
+			"negative size":    {Name: "Q", MaxSize: -1},
+			"unknown policy":   {Name: "Q", OverflowPolicy: "drop"},
+			"redirect nowhere": {Name: "Q", MaxSize: 1, OverflowPolicy: "redirect"},
+			"missing overflow": {Name: "Q", MaxSize: 1, OverflowPolicy: "redirect", OverflowQueueId: 99},
+		} {
+			_, err := server.CreateQueue(ctx, req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+		self := int32(1)
+		_, err := server.UpdateQueue(ctx, &pb.UpdateQueueRequest{Id: 1, Name: "Small", OverflowQueueId: &self})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Redirect", func(t *testing.T) {
+		_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Overflow"})
+		require.NoError(t, err)
+		policy, overflow := "redirect", int32(2)
+		_, err = server.UpdateQueue(ctx, &pb.UpdateQueueRequest{Id: 1, Name: "Small", OverflowPolicy: &policy, OverflowQueueId: &overflow})
+		require.NoError(t, err)
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "redirect", resp.OverflowPolicy)
+	})
+
+	t.Run("PromoteOnResize", func(t *testing.T) {
+		size := int32(3)
+		_, err := server.UpdateQueue(ctx, &pb.UpdateQueueRequest{Id: 1, Name: "Small", MaxSize: &size})
+		require.NoError(t, err)
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B", "C"}, resp.Clients)
+		assert.Equal(t, float64(1), resp.FillRatio)
+		assert.Zero(t, resp.WaitlistedCount)
+	})
+}
+
+func TestQueueStates(t *testing.T) {
+	setupTestDB()
+	// The notifier only enqueues: nothing delivers the outbox.
+	server := NewQueueManagementService(repository.NewPostgresQueueRepository(testDB), repository.NewPostgresClientRepository(testDB),
+		repository.NewPostgresCounterRepository(testDB), notifier.New(notifier.DefaultThreshold, nil))
+	ctx := context.Background()
+
+	_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Cash desk"})
+	require.NoError(t, err)
+	openCounter(t, 1, 1)
+	_, err = testDB.Exec("INSERT INTO clients (name, email, queue_id, ticket_number) VALUES ('A', 'a@example.com', 1, '001'), ('B', '', 1, '002')")
+	require.NoError(t, err)
+
+	resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "open", resp.State)
+	assert.True(t, resp.Open)
+
+	t.Run("Pause", func(t *testing.T) {
+		paused, err := server.PauseQueue(ctx, &pb.PauseQueueRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "paused", paused.State)
+
+		_, err = server.CallNext(ctx, &pb.CallNextRequest{QueueId: 1, CounterId: 1})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "paused", resp.State)
+		assert.True(t, resp.Open, "paused queues take clients")
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		_, err := server.CloseQueue(ctx, &pb.CloseQueueRequest{Id: 1})
+		require.NoError(t, err)
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "closed", resp.State)
+		assert.False(t, resp.Open)
+		assert.Nil(t, resp.NextOpening)
+
+		called, err := server.CallNext(ctx, &pb.CallNextRequest{QueueId: 1, CounterId: 1})
+		require.NoError(t, err, "closed queues keep serving")
+		assert.Equal(t, "A", called.Client.Name)
+	})
+
+	t.Run("Drain", func(t *testing.T) {
+		_, err := testDB.Exec("INSERT INTO clients (name, email, queue_id, ticket_number) VALUES ('C', 'c@example.com', 1, '003')")
+		require.NoError(t, err)
+		drained, err := server.DrainQueue(ctx, &pb.DrainQueueRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "draining", drained.State)
+
+		var notices int
+		require.NoError(t, testDB.QueryRow("SELECT COUNT(*) FROM notification_outbox WHERE event = 'queue_closed'").Scan(&notices))
+		assert.Equal(t, 1, notices, "only the waiting client with an email is told")
+	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		_, err := server.ReopenQueue(ctx, &pb.ReopenQueueRequest{Id: 1})
+		require.NoError(t, err)
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "open", resp.State)
+		assert.True(t, resp.Open)
+	})
+
+	t.Run("DrainAgain", func(t *testing.T) {
+		notices := func() int {
+			var n int
+			require.NoError(t, testDB.QueryRow("SELECT COUNT(*) FROM notification_outbox WHERE event = 'queue_closed'").Scan(&n))
+			return n
+		}
+		drainAndReopen := func() {
+			_, err := server.DrainQueue(ctx, &pb.DrainQueueRequest{Id: 1})
+			require.NoError(t, err)
+			_, err = server.ReopenQueue(ctx, &pb.ReopenQueueRequest{Id: 1})
+			require.NoError(t, err)
+		}
+
+		drainAndReopen()
+		assert.Equal(t, 1, notices(), "C was told of today's drain already")
+
+		// The earlier notice was for a drain on another day.
+		_, err := testDB.Exec("UPDATE notification_outbox SET dedup_key = 'queue_closed:drain:2000-01-01:' || split_part(dedup_key, ':', 4)")
+		require.NoError(t, err)
+		drainAndReopen()
+		assert.Equal(t, 2, notices(), "C is told of a drain on a new day")
+
+		_, err = server.queues.SetScheduleClosed(ctx, 1, true, server.notifier)
+		require.NoError(t, err)
+		assert.Equal(t, 3, notices(), "C is told of the scheduled closing apart from the drain")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := server.PauseQueue(ctx, &pb.PauseQueueRequest{Id: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = server.ReopenQueue(ctx, &pb.ReopenQueueRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 }
 
 func TestUpdateQueue(t *testing.T) {
 	setupTestDB()
-	server := NewQueueManagementService(testDB)
+	server := newTestServer()
 
 	// First, create a queue to update
 	_, err := server.CreateQueue(context.Background(), &pb.CreateQueueRequest{Name: "Test Queue"})
@@ -104,11 +363,23 @@ func TestUpdateQueue(t *testing.T) {
 		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		assert.Equal(t, "Queue ID and name are required", status.Convert(err).Message())
 	})
+
+	t.Run("TicketPrefix", func(t *testing.T) {
+		prefix := "B"
+		_, err := server.UpdateQueue(context.Background(), &pb.UpdateQueueRequest{Id: 1, Name: "Updated Queue", TicketPrefix: &prefix})
+		require.NoError(t, err)
+
+		var padding int32
+		err = testDB.QueryRow("SELECT ticket_prefix, ticket_padding FROM queues WHERE id = 1").Scan(&prefix, &padding)
+		require.NoError(t, err)
+		assert.Equal(t, "B", prefix)
+		assert.Equal(t, int32(3), padding)
+	})
 }
 
 func TestDeleteQueue(t *testing.T) {
 	setupTestDB()
-	server := NewQueueManagementService(testDB)
+	server := newTestServer()
 
 	// First, create a queue to delete
 	_, err := server.CreateQueue(context.Background(), &pb.CreateQueueRequest{Name: "Test Queue"})
@@ -129,11 +400,63 @@ func TestDeleteQueue(t *testing.T) {
 		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		assert.Equal(t, "Queue ID is required", status.Convert(err).Message())
 	})
+
+	t.Run("ActiveClients", func(t *testing.T) {
+		_, err := server.CreateQueue(context.Background(), &pb.CreateQueueRequest{Name: "Busy Queue"})
+		require.NoError(t, err)
+		_, err = testDB.Exec(`INSERT INTO clients (name, queue_id, status) VALUES
+			('Client A', 2, 'waiting'), ('Client B', 2, 'served'), ('Client C', 2, 'cancelled')`)
+		require.NoError(t, err)
+
+		_, err = server.DeleteQueue(context.Background(), &pb.DeleteQueueRequest{Id: 2})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "Queue still has 1 active clients", status.Convert(err).Message())
+
+		_, err = testDB.Exec("UPDATE clients SET status = 'cancelled' WHERE name = 'Client A'")
+		require.NoError(t, err)
+		resp, err := server.DeleteQueue(context.Background(), &pb.DeleteQueueRequest{Id: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), resp.AffectedClients)
+
+		var kept int
+		require.NoError(t, testDB.QueryRow("SELECT COUNT(*) FROM clients WHERE queue_id = 2").Scan(&kept))
+		assert.Equal(t, 3, kept, "the history of a deleted queue is kept")
+	})
+}
+
+func TestRestoreQueue(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+	ctx := context.Background()
+
+	_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Test Queue"})
+	require.NoError(t, err)
+	_, err = server.DeleteQueue(ctx, &pb.DeleteQueueRequest{Id: 1})
+	require.NoError(t, err)
+
+	_, err = server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	deleted, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1, IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Test Queue", deleted.Name)
+	assert.NotNil(t, deleted.DeletedAt)
+
+	resp, err := server.RestoreQueue(ctx, &pb.RestoreQueueRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Queue restored successfully", resp.Message)
+
+	restored, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+
+	_, err = server.RestoreQueue(ctx, &pb.RestoreQueueRequest{Id: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "Deleted queue not found", status.Convert(err).Message())
 }
 
 func TestGetQueueStatus(t *testing.T) {
 	setupTestDB()
-	server := NewQueueManagementService(testDB)
+	server := newTestServer()
 
 	_, err := server.CreateQueue(context.Background(), &pb.CreateQueueRequest{Name: "Test Queue"})
 	require.NoError(t, err)
@@ -149,6 +472,15 @@ func TestGetQueueStatus(t *testing.T) {
 		assert.Equal(t, "Test Queue", resp.Name)
 		assert.ElementsMatch(t, []string{"Client A", "Client B", "Client C"}, resp.Clients)
 		assert.Equal(t, "Queue status retrieved successfully", resp.Message)
+
+		require.Len(t, resp.Entries, 3)
+		for i, entry := range resp.Entries {
+			assert.Equal(t, resp.Clients[i], entry.Name)
+			assert.Equal(t, int32(i+1), entry.Position)
+			assert.Equal(t, "waiting", entry.Status)
+			assert.NotNil(t, entry.JoinedAt)
+		}
+		assert.Equal(t, int32(3), resp.TotalCount)
 	})
 
 	t.Run("WithFilter", func(t *testing.T) {
@@ -168,6 +500,8 @@ func TestGetQueueStatus(t *testing.T) {
 		assert.Equal(t, int32(1), resp.Id)
 		assert.Equal(t, "Test Queue", resp.Name)
 		assert.ElementsMatch(t, []string{"Client B", "Client C"}, resp.Clients)
+		assert.Len(t, resp.Entries, 2)
+		assert.Equal(t, int32(3), resp.TotalCount)
 		assert.Equal(t, "Queue status retrieved successfully", resp.Message)
 	})
 
@@ -181,6 +515,31 @@ func TestGetQueueStatus(t *testing.T) {
 		assert.Equal(t, "Queue status retrieved successfully", resp.Message)
 	})
 
+	t.Run("WithSortKeys", func(t *testing.T) {
+		_, err := testDB.Exec("UPDATE clients SET priority = 1 WHERE name = 'Client B'")
+		require.NoError(t, err)
+		defer testDB.Exec("UPDATE clients SET priority = 0")
+
+		req := &pb.GetQueueStatusRequest{Id: 1, Sort: []*pb.SortKey{
+			{Field: pb.SortField_SORT_FIELD_PRIORITY, Descending: true},
+			{Field: pb.SortField_SORT_FIELD_NAME},
+		}}
+		resp, err := server.GetQueueStatus(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Client B", "Client A", "Client C"}, resp.Clients)
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		for _, req := range []*pb.GetQueueStatusRequest{
+			{Id: 1, SortBy: "name; DROP TABLE clients"},
+			{Id: 1, SortBy: "name", SortOrder: "sideways"},
+			{Id: 1, Sort: []*pb.SortKey{{Field: pb.SortField_SORT_FIELD_UNSPECIFIED}}},
+		} {
+			_, err := server.GetQueueStatus(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), req.String())
+		}
+	})
+
 	t.Run("InvalidID", func(t *testing.T) {
 		req := &pb.GetQueueStatusRequest{Id: 0}
 		_, err := server.GetQueueStatus(context.Background(), req)
@@ -197,3 +556,499 @@ func TestGetQueueStatus(t *testing.T) {
 		assert.Equal(t, "Queue not found", status.Convert(err).Message())
 	})
 }
+
+func TestListQueues(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+	ctx := context.Background()
+
+	for _, name := range []string{"Cash desk", "Loans", "Express cash"} {
+		_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: name})
+		require.NoError(t, err)
+	}
+	_, err := testDB.Exec("INSERT INTO clients (name, queue_id) VALUES ('Client A', 1), ('Client B', 1), ('Client C', 3)")
+	require.NoError(t, err)
+
+	first, err := server.ListQueues(ctx, &pb.ListQueuesRequest{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Queues, 2)
+	assert.Equal(t, "Cash desk", first.Queues[0].Name)
+	assert.Equal(t, "active", first.Queues[0].Status)
+	assert.Equal(t, int32(2), first.Queues[0].WaitingCount)
+	assert.NotEmpty(t, first.NextPageToken)
+
+	// A queue created while paging shows up on the last page only.
+	_, err = server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Late cash"})
+	require.NoError(t, err)
+	second, err := server.ListQueues(ctx, &pb.ListQueuesRequest{PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, second.Queues, 2)
+	assert.Equal(t, "Express cash", second.Queues[0].Name)
+	assert.Equal(t, int32(1), second.Queues[0].WaitingCount)
+	assert.Equal(t, "Late cash", second.Queues[1].Name)
+	assert.Empty(t, second.NextPageToken)
+
+	_, err = server.DeleteQueue(ctx, &pb.DeleteQueueRequest{Id: 2})
+	require.NoError(t, err)
+	cash, err := server.ListQueues(ctx, &pb.ListQueuesRequest{NameFilter: "cash", Statuses: []string{"active", "deleted"}})
+	require.NoError(t, err)
+	assert.Len(t, cash.Queues, 3)
+	deleted, err := server.ListQueues(ctx, &pb.ListQueuesRequest{Statuses: []string{"deleted"}})
+	require.NoError(t, err)
+	require.Len(t, deleted.Queues, 1)
+	assert.Equal(t, "Loans", deleted.Queues[0].Name)
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		_, err := server.ListQueues(ctx, &pb.ListQueuesRequest{PageToken: "not a token"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "Invalid page token", status.Convert(err).Message())
+
+		_, err = server.ListQueues(ctx, &pb.ListQueuesRequest{Statuses: []string{"archived"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = server.ListQueues(ctx, &pb.ListQueuesRequest{PageSize: 1000})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCallNext(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+
+	_, err := server.CreateQueue(context.Background(), &pb.CreateQueueRequest{Name: "Test Queue"})
+	require.NoError(t, err)
+	openCounter(t, 7, 1)
+	openCounter(t, 8, 1)
+	_, err = testDB.Exec(`INSERT INTO clients (name, queue_id, email, created_at) VALUES
+		($1, 1, 'a@example.com', NOW() - INTERVAL '2 minutes'),
+		($2, 1, 'b@example.com', NOW() - INTERVAL '1 minute')`,
+		"Client A", "Client B")
+	require.NoError(t, err)
+
+	t.Run("CallsOldestFirst", func(t *testing.T) {
+		resp, err := server.CallNext(context.Background(), &pb.CallNextRequest{QueueId: 1, CounterId: 7})
+		require.NoError(t, err)
+		assert.Equal(t, "Client A", resp.Client.Name)
+		assert.Equal(t, "a@example.com", resp.Client.Email)
+		assert.Equal(t, int32(7), resp.Client.CounterId)
+		assert.NotNil(t, resp.Client.CalledAt)
+
+		resp, err = server.CallNext(context.Background(), &pb.CallNextRequest{QueueId: 1, CounterId: 8})
+		require.NoError(t, err)
+		assert.Equal(t, "Client B", resp.Client.Name)
+	})
+
+	t.Run("QueueEmpty", func(t *testing.T) {
+		_, err := server.CallNext(context.Background(), &pb.CallNextRequest{QueueId: 1, CounterId: 7})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "No clients waiting in queue", status.Convert(err).Message())
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		_, err := server.CallNext(context.Background(), &pb.CallNextRequest{QueueId: 1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "Queue ID and counter ID are required", status.Convert(err).Message())
+	})
+
+	t.Run("QueueNotFound", func(t *testing.T) {
+		_, err := server.CallNext(context.Background(), &pb.CallNextRequest{QueueId: 999, CounterId: 7})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "Queue not found", status.Convert(err).Message())
+	})
+
+	t.Run("CounterNotFound", func(t *testing.T) {
+		_, err := server.CallNext(context.Background(), &pb.CallNextRequest{QueueId: 1, CounterId: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "Counter not found", status.Convert(err).Message())
+	})
+
+	t.Run("CounterNotAssigned", func(t *testing.T) {
+		_, err := server.CreateQueue(context.Background(), &pb.CreateQueueRequest{Name: "Other Queue"})
+		require.NoError(t, err)
+		_, err = server.CallNext(context.Background(), &pb.CallNextRequest{QueueId: 2, CounterId: 7})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "Counter is not assigned to the queue", status.Convert(err).Message())
+	})
+
+	t.Run("CounterClosed", func(t *testing.T) {
+		_, err := testDB.Exec("UPDATE counters SET open = FALSE WHERE id = 8")
+		require.NoError(t, err)
+		_, err = server.CallNext(context.Background(), &pb.CallNextRequest{QueueId: 1, CounterId: 8})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "Counter is closed", status.Convert(err).Message())
+	})
+}
+
+func TestCounterService(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+	counters := NewCounterService(repository.NewPostgresCounterRepository(testDB))
+	ctx := context.Background()
+
+	for _, name := range []string{"Cash desk", "Loans"} {
+		_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: name})
+		require.NoError(t, err)
+	}
+	_, err := testDB.Exec("INSERT INTO clients (name, queue_id) VALUES ('A', 1), ('B', 2)")
+	require.NoError(t, err)
+
+	t.Run("Create", func(t *testing.T) {
+		resp, err := counters.CreateCounter(ctx, &pb.CreateCounterRequest{Name: "Window 1", QueueIds: []int32{2, 1}})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), resp.Counter.Id)
+		assert.Equal(t, []int32{1, 2}, resp.Counter.QueueIds)
+		assert.False(t, resp.Counter.Open)
+
+		_, err = counters.CreateCounter(ctx, &pb.CreateCounterRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = counters.CreateCounter(ctx, &pb.CreateCounterRequest{Name: "Window 2", QueueIds: []int32{999}})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("OpenAndClose", func(t *testing.T) {
+		_, err := server.CallNext(ctx, &pb.CallNextRequest{QueueId: 1, CounterId: 1})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		opened, err := counters.OpenCounter(ctx, &pb.OpenCounterRequest{Id: 1})
+		require.NoError(t, err)
+		assert.True(t, opened.Counter.Open)
+		called, err := server.CallNext(ctx, &pb.CallNextRequest{QueueId: 1, CounterId: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "A", called.Client.Name)
+
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), resp.OpenCounters)
+		require.Len(t, resp.Entries, 1)
+		assert.Equal(t, int32(1), resp.Entries[0].CounterId)
+
+		closed, err := counters.CloseCounter(ctx, &pb.CloseCounterRequest{Id: 1})
+		require.NoError(t, err)
+		assert.False(t, closed.Counter.Open)
+		_, err = counters.OpenCounter(ctx, &pb.OpenCounterRequest{Id: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Assign", func(t *testing.T) {
+		resp, err := counters.AssignCounter(ctx, &pb.AssignCounterRequest{Id: 1, QueueIds: []int32{2}})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{2}, resp.Counter.QueueIds)
+
+		listed, err := counters.ListCounters(ctx, &pb.ListCountersRequest{QueueId: 1})
+		require.NoError(t, err)
+		assert.Empty(t, listed.Counters)
+		listed, err = counters.ListCounters(ctx, &pb.ListCountersRequest{QueueId: 2})
+		require.NoError(t, err)
+		require.Len(t, listed.Counters, 1)
+		assert.Equal(t, "Window 1", listed.Counters[0].Name)
+
+		_, err = counters.AssignCounter(ctx, &pb.AssignCounterRequest{Id: 1, QueueIds: []int32{999}})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		got, err := counters.GetCounter(ctx, &pb.GetCounterRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{2}, got.Counter.QueueIds)
+	})
+}
+
+func TestClientLifecycle(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+	ctx := context.Background()
+
+	_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Test Queue"})
+	require.NoError(t, err)
+	openCounter(t, 1, 1)
+	_, err = testDB.Exec("INSERT INTO clients (name, queue_id) VALUES ($1, 1), ($2, 1), ($3, 1)",
+		"Client A", "Client B", "Client C")
+	require.NoError(t, err)
+
+	t.Run("CalledServingServed", func(t *testing.T) {
+		called, err := server.CallNext(ctx, &pb.CallNextRequest{QueueId: 1, CounterId: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "called", called.Client.Status)
+
+		started, err := server.StartService(ctx, &pb.StartServiceRequest{ClientId: called.Client.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "serving", started.Client.Status)
+		assert.NotNil(t, started.Client.ServingStartedAt)
+
+		completed, err := server.CompleteService(ctx, &pb.CompleteServiceRequest{ClientId: called.Client.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "served", completed.Client.Status)
+		assert.NotNil(t, completed.Client.ServedAt)
+
+		_, err = server.CompleteService(ctx, &pb.CompleteServiceRequest{ClientId: called.Client.Id})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "Cannot move client from served to served", status.Convert(err).Message())
+	})
+
+	t.Run("NoShow", func(t *testing.T) {
+		called, err := server.CallNext(ctx, &pb.CallNextRequest{QueueId: 1, CounterId: 1})
+		require.NoError(t, err)
+
+		resp, err := server.MarkNoShow(ctx, &pb.MarkNoShowRequest{ClientId: called.Client.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "no_show", resp.Client.Status)
+		assert.NotNil(t, resp.Client.NoShowAt)
+	})
+
+	t.Run("CancelWaiting", func(t *testing.T) {
+		resp, err := server.CancelTicket(ctx, &pb.CancelTicketRequest{ClientId: 3})
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled", resp.Client.Status)
+
+		_, err = server.StartService(ctx, &pb.StartServiceRequest{ClientId: 3})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("OnlyActiveClientsListed", func(t *testing.T) {
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Clients)
+	})
+
+	t.Run("ClientNotFound", func(t *testing.T) {
+		_, err := server.StartService(ctx, &pb.StartServiceRequest{ClientId: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "Client not found", status.Convert(err).Message())
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := server.CancelTicket(ctx, &pb.CancelTicketRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "Client ID is required", status.Convert(err).Message())
+	})
+}
+
+func TestGetQueueStatusEstimatedWait(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+	ctx := context.Background()
+
+	_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Test Queue"})
+	require.NoError(t, err)
+	openCounter(t, 1, 1)
+
+	t.Run("NoHistory", func(t *testing.T) {
+		_, err := testDB.Exec("INSERT INTO clients (name, queue_id) VALUES ($1, 1)", "Client A")
+		require.NoError(t, err)
+
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int32(eta.DefaultServiceSeconds), resp.EstimatedWaitSeconds)
+	})
+
+	t.Run("FromServiceHistory", func(t *testing.T) {
+		_, err := testDB.Exec(`INSERT INTO clients (name, queue_id, status, counter_id, called_at, serving_started_at, served_at) VALUES
+			('Served', 1, 'served', 1, NOW() - INTERVAL '5 minutes', NOW() - INTERVAL '4 minutes', NOW() - INTERVAL '2 minutes')`)
+		require.NoError(t, err)
+		_, err = testDB.Exec("INSERT INTO clients (name, queue_id) VALUES ($1, 1), ($2, 1)", "Client B", "Client C")
+		require.NoError(t, err)
+
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3*120), resp.EstimatedWaitSeconds)
+		assert.Equal(t, int32(1), resp.OpenCounters)
+	})
+
+	t.Run("SharedByOpenCounters", func(t *testing.T) {
+		openCounter(t, 2, 1)
+		resp, err := server.GetQueueStatus(ctx, &pb.GetQueueStatusRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), resp.OpenCounters)
+		assert.Equal(t, int32(3*120/2), resp.EstimatedWaitSeconds)
+	})
+}
+
+func TestCallNextOrderingPolicies(t *testing.T) {
+	setupTestDB()
+	server := newTestServer()
+	ctx := context.Background()
+
+	callAll := func(t *testing.T, queueID int32) []string {
+		var names []string
+		for {
+			resp, err := server.CallNext(ctx, &pb.CallNextRequest{QueueId: queueID, CounterId: 1})
+			if status.Code(err) == codes.NotFound {
+				return names
+			}
+			require.NoError(t, err)
+			names = append(names, resp.Client.Name)
+		}
+	}
+
+	t.Run("Strict", func(t *testing.T) {
+		_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Strict Queue"})
+		require.NoError(t, err)
+		openCounter(t, 1, 1)
+		_, err = testDB.Exec(`INSERT INTO clients (name, queue_id, priority, created_at) VALUES
+			('A', 1, 0, NOW() - INTERVAL '3 minutes'),
+			('B', 1, 1, NOW() - INTERVAL '2 minutes'),
+			('C', 1, 0, NOW() - INTERVAL '1 minute')`)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"B", "A", "C"}, callAll(t, 1))
+	})
+
+	t.Run("Weighted", func(t *testing.T) {
+		_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Weighted Queue", OrderingPolicy: "weighted"})
+		require.NoError(t, err)
+		openCounter(t, 1, 2)
+		_, err = testDB.Exec(`INSERT INTO clients (name, queue_id, priority, created_at) VALUES
+			('A', 2, 0, NOW() - INTERVAL '6 minutes'),
+			('B', 2, 0, NOW() - INTERVAL '5 minutes'),
+			('C', 2, 0, NOW() - INTERVAL '4 minutes'),
+			('X', 2, 1, NOW() - INTERVAL '3 minutes'),
+			('Y', 2, 1, NOW() - INTERVAL '2 minutes'),
+			('Z', 2, 1, NOW() - INTERVAL '1 minute')`)
+		require.NoError(t, err)
+
+		// Tier 1 has twice the weight of tier 0, so it gets two of every three calls.
+		assert.Equal(t, []string{"X", "Y", "A", "Z", "B", "C"}, callAll(t, 2))
+	})
+
+	t.Run("Aging", func(t *testing.T) {
+		_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Aging Queue", OrderingPolicy: "aging", AgingSeconds: 60})
+		require.NoError(t, err)
+		openCounter(t, 1, 3)
+		_, err = testDB.Exec(`INSERT INTO clients (name, queue_id, priority, created_at) VALUES
+			('Old', 3, 0, NOW() - INTERVAL '5 minutes'),
+			('VIP', 3, 2, NOW())`)
+		require.NoError(t, err)
+
+		// Five minutes of waiting promote the regular client above tier 2.
+		assert.Equal(t, []string{"Old", "VIP"}, callAll(t, 3))
+	})
+
+	t.Run("UpdatePolicy", func(t *testing.T) {
+		policy := "weighted"
+		_, err := server.UpdateQueue(ctx, &pb.UpdateQueueRequest{Id: 1, Name: "Strict Queue", OrderingPolicy: &policy})
+		require.NoError(t, err)
+
+		var stored string
+		require.NoError(t, testDB.QueryRow("SELECT ordering_policy FROM queues WHERE id = 1").Scan(&stored))
+		assert.Equal(t, "weighted", stored)
+	})
+
+	t.Run("UnknownPolicy", func(t *testing.T) {
+		_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Bad Queue", OrderingPolicy: "random"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "Unknown ordering policy", status.Convert(err).Message())
+	})
+}
+
+// fakeNotificationClient records the notifications it is asked to send and
+// fails with err when set.
+type fakeNotificationClient struct {
+	notificationpb.NotificationServiceClient
+	sent chan *notificationpb.SendNotificationRequest
+	err  error
+}
+
+func (f *fakeNotificationClient) SendNotification(ctx context.Context, req *notificationpb.SendNotificationRequest, opts ...grpc.CallOption) (*notificationpb.SendNotificationResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.sent <- req
+	return &notificationpb.SendNotificationResponse{Success: true}, nil
+}
+
+func TestCallNextNotifications(t *testing.T) {
+	setupTestDB()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fake := &fakeNotificationClient{sent: make(chan *notificationpb.SendNotificationRequest, 16)}
+	dispatcher := notifier.NewDispatcher(testDB, fake)
+	go dispatcher.Run(ctx)
+	clients := repository.NewPostgresClientRepository(testDB)
+	n := notifier.New(1, dispatcher)
+	server := NewQueueManagementService(repository.NewPostgresQueueRepository(testDB), clients, repository.NewPostgresCounterRepository(testDB), n)
+
+	_, err := server.CreateQueue(ctx, &pb.CreateQueueRequest{Name: "Test Queue"})
+	require.NoError(t, err)
+	openCounter(t, 4, 1)
+	_, err = testDB.Exec(`INSERT INTO clients (name, queue_id, email, ticket_number, created_at) VALUES
+		('A', 1, 'a@example.com', '001', NOW() - INTERVAL '3 minutes'),
+		('B', 1, 'b@example.com', '002', NOW() - INTERVAL '2 minutes'),
+		('C', 1, 'c@example.com', '003', NOW() - INTERVAL '1 minute')`)
+	require.NoError(t, err)
+
+	next := func() *notificationpb.SendNotificationRequest {
+		select {
+		case req := <-fake.sent:
+			return req
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notification sent")
+			return nil
+		}
+	}
+
+	// A is called; B and C now have at most one client ahead of them.
+	_, err = server.CallNext(ctx, &pb.CallNextRequest{QueueId: 1, CounterId: 4})
+	require.NoError(t, err)
+	called := next()
+	assert.Equal(t, "a@example.com", called.Email)
+	assert.Equal(t, "email", called.Channel)
+	assert.Contains(t, called.Message, "counter 4")
+	assert.Equal(t, "b@example.com", next().Email)
+	assert.Equal(t, "c@example.com", next().Email)
+
+	// B is called: only the called alert is new, C was already told.
+	_, err = server.CallNext(ctx, &pb.CallNextRequest{QueueId: 1, CounterId: 4})
+	require.NoError(t, err)
+	assert.Equal(t, "b@example.com", next().Email)
+	select {
+	case req := <-fake.sent:
+		t.Fatalf("unexpected notification to %s", req.Email)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	var sent int
+	require.NoError(t, testDB.QueryRow("SELECT COUNT(*) FROM notification_outbox WHERE status = 'sent'").Scan(&sent))
+	assert.Equal(t, 4, sent)
+}
+
+func TestOutboxRetries(t *testing.T) {
+	setupTestDB()
+	ctx := context.Background()
+
+	fake := &fakeNotificationClient{
+		sent: make(chan *notificationpb.SendNotificationRequest, 16),
+		err:  status.Error(codes.Unavailable, "smtp down"),
+	}
+	dispatcher := notifier.NewDispatcher(testDB, fake)
+	dispatcher.MaxAttempts = 2
+	n := notifier.New(notifier.DefaultThreshold, dispatcher)
+
+	require.NoError(t, n.Enqueue(ctx, testDB, notifier.Message{Event: "test", Channel: "email", Recipient: "a@example.com", Body: "Hello"}))
+
+	outbox := func() (state string, attempts int, lastError sql.NullString) {
+		err := testDB.QueryRow("SELECT status, attempts, last_error FROM notification_outbox WHERE id = 1").
+			Scan(&state, &attempts, &lastError)
+		require.NoError(t, err)
+		return
+	}
+
+	// The first failure schedules a retry after the backoff delay.
+	claimed, err := dispatcher.DispatchBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+	state, attempts, lastError := outbox()
+	assert.Equal(t, "pending", state)
+	assert.Equal(t, 1, attempts)
+	assert.Contains(t, lastError.String, "smtp down")
+
+	claimed, err = dispatcher.DispatchBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, claimed, "message retried before its backoff expired")
+
+	// Out of attempts: the message is dead-lettered.
+	_, err = testDB.Exec("UPDATE notification_outbox SET next_attempt_at = NOW()")
+	require.NoError(t, err)
+	_, err = dispatcher.DispatchBatch(ctx)
+	require.NoError(t, err)
+	state, attempts, _ = outbox()
+	assert.Equal(t, "dead", state)
+	assert.Equal(t, 2, attempts)
+}
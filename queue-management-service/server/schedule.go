@@ -0,0 +1,34 @@
+package server
+
+import (
+	"time"
+
+	"queue-management-system/queue-management-service/pb"
+	"queue-management-system/schedule"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fromPBSchedule converts and validates the schedule of a request.
+func fromPBSchedule(s *pb.Schedule) (*schedule.Schedule, error) {
+	converted := &schedule.Schedule{TimeZone: s.TimeZone, Holidays: s.Holidays, NotifyOnClose: s.NotifyOnClose}
+	for _, h := range s.Weekly {
+		converted.Weekly = append(converted.Weekly, schedule.Hours{Day: time.Weekday(h.Day), Open: h.Open, Close: h.Close})
+	}
+	if err := converted.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid schedule: %v", err)
+	}
+	return converted, nil
+}
+
+func toPBSchedule(s *schedule.Schedule) *pb.Schedule {
+	if s == nil {
+		return nil
+	}
+	converted := &pb.Schedule{TimeZone: s.TimeZone, Holidays: s.Holidays, NotifyOnClose: s.NotifyOnClose}
+	for _, h := range s.Weekly {
+		converted.Weekly = append(converted.Weekly, &pb.OpeningHours{Day: int32(h.Day), Open: h.Open, Close: h.Close})
+	}
+	return converted
+}
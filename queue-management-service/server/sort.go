@@ -0,0 +1,50 @@
+package server
+
+import (
+	"queue-management-system/queue-management-service/pb"
+	"queue-management-system/queue-management-service/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sortFields maps the sort fields of the API to the repository's.
+var sortFields = map[pb.SortField]repository.SortField{
+	pb.SortField_SORT_FIELD_JOINED_AT:     repository.SortJoinedAt,
+	pb.SortField_SORT_FIELD_NAME:          repository.SortName,
+	pb.SortField_SORT_FIELD_PRIORITY:      repository.SortPriority,
+	pb.SortField_SORT_FIELD_TICKET_NUMBER: repository.SortTicketNumber,
+	pb.SortField_SORT_FIELD_POSITION:      repository.SortPosition,
+	pb.SortField_SORT_FIELD_STATUS:        repository.SortStatus,
+	pb.SortField_SORT_FIELD_ID:            repository.SortID,
+	pb.SortField_SORT_FIELD_EMAIL:         repository.SortEmail,
+}
+
+// clientSort returns the sort keys of a GetQueueStatus request. Requests
+// without sort keys may still name a single field in sort_by, which also
+// accepts created_at, its former name for joined_at.
+func clientSort(req *pb.GetQueueStatusRequest) ([]repository.SortKey, error) {
+	if len(req.Sort) > 0 {
+		keys := make([]repository.SortKey, 0, len(req.Sort))
+		for _, key := range req.Sort {
+			field, ok := sortFields[key.Field]
+			if !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "Unknown sort field %q", key.Field.String())
+			}
+			keys = append(keys, repository.SortKey{Field: field, Descending: key.Descending})
+		}
+		return keys, nil
+	}
+
+	if req.SortOrder != "" && req.SortOrder != "asc" && req.SortOrder != "desc" {
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown sort order %q", req.SortOrder)
+	}
+	if req.SortBy == "" {
+		return nil, nil
+	}
+	field := repository.SortField(req.SortBy)
+	if req.SortBy == "created_at" {
+		field = repository.SortJoinedAt
+	}
+	return []repository.SortKey{{Field: field, Descending: req.SortOrder == "desc"}}, nil
+}
@@ -0,0 +1,167 @@
+// Package schedule describes when a queue is open: weekly opening hours in
+// the queue's time zone, minus holidays. Both services read the schedule
+// stored with a queue, so they agree on when it takes clients.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	// Services run in slim containers without a zone database.
+	_ "time/tzdata"
+)
+
+// dateLayout is the format of holiday dates.
+const dateLayout = "2006-01-02"
+
+// lookahead bounds how far NextOpening searches. Holidays are single dates,
+// so a schedule that opens at all opens within a year and a week.
+const lookahead = 366 + 7
+
+// Schedule is the opening hours of a queue. A queue without a schedule is
+// always open.
+type Schedule struct {
+	// TimeZone is an IANA zone name such as "Asia/Almaty". Empty means UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+	// Weekly lists the opening hours of the week. Days without hours are
+	// closed, and a day may have several, e.g. around a lunch break.
+	Weekly []Hours `json:"weekly"`
+	// Holidays are dates, formatted 2006-01-02 in TimeZone, on which the
+	// queue stays closed.
+	Holidays []string `json:"holidays,omitempty"`
+	// NotifyOnClose tells the clients still waiting when the queue closes.
+	NotifyOnClose bool `json:"notify_on_close,omitempty"`
+}
+
+// Hours is one opening of a weekday, from Open until Close. Both are
+// formatted 15:04; Close may be 24:00 for midnight.
+type Hours struct {
+	Day   time.Weekday `json:"day"`
+	Open  string       `json:"open"`
+	Close string       `json:"close"`
+}
+
+// Validate checks that the schedule can be evaluated.
+func (s *Schedule) Validate() error {
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return fmt.Errorf("unknown time zone %q", s.TimeZone)
+	}
+	for _, h := range s.Weekly {
+		if h.Day < time.Sunday || h.Day > time.Saturday {
+			return fmt.Errorf("invalid weekday %d", h.Day)
+		}
+		from, err := minutes(h.Open)
+		if err != nil {
+			return err
+		}
+		until, err := minutes(h.Close)
+		if err != nil {
+			return err
+		}
+		if from >= until {
+			return fmt.Errorf("%s hours close at %s before opening at %s", h.Day, h.Close, h.Open)
+		}
+	}
+	for _, date := range s.Holidays {
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return fmt.Errorf("invalid holiday %q", date)
+		}
+	}
+	return nil
+}
+
+// IsOpen reports whether the queue is open at t.
+func (s *Schedule) IsOpen(t time.Time) bool {
+	for _, span := range s.spans(t.In(s.location())) {
+		if !t.Before(span.open) && t.Before(span.close) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpening returns the first time at or after t at which the queue is
+// open, and false if it never opens.
+func (s *Schedule) NextOpening(t time.Time) (time.Time, bool) {
+	local := t.In(s.location())
+	for i := 0; i < lookahead; i++ {
+		var next time.Time
+		for _, span := range s.spans(local.AddDate(0, 0, i)) {
+			if !t.Before(span.close) {
+				continue
+			}
+			start := span.open
+			if start.Before(t) {
+				start = t
+			}
+			if next.IsZero() || start.Before(next) {
+				next = start
+			}
+		}
+		if !next.IsZero() {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+type span struct {
+	open, close time.Time
+}
+
+// spans returns the opening hours of the day of local, none on holidays.
+func (s *Schedule) spans(local time.Time) []span {
+	year, month, day := local.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, local.Location())
+	for _, holiday := range s.Holidays {
+		if holiday == date.Format(dateLayout) {
+			return nil
+		}
+	}
+
+	var spans []span
+	for _, h := range s.Weekly {
+		if h.Day != date.Weekday() {
+			continue
+		}
+		from, err := minutes(h.Open)
+		if err != nil {
+			continue
+		}
+		until, err := minutes(h.Close)
+		if err != nil {
+			continue
+		}
+		// time.Date normalizes 24:00 to midnight of the next day and moves
+		// times skipped by daylight saving past the gap.
+		spans = append(spans, span{
+			open:  time.Date(year, month, day, from/60, from%60, 0, 0, date.Location()),
+			close: time.Date(year, month, day, until/60, until%60, 0, 0, date.Location()),
+		})
+	}
+	return spans
+}
+
+// location returns the time zone of the schedule, UTC if it is invalid.
+func (s *Schedule) location() *time.Location {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+var errTimeOfDay = errors.New("times of day must be formatted 15:04")
+
+// minutes parses a time of day into minutes after midnight.
+func minutes(clock string) (int, error) {
+	if clock == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%w, got %q", errTimeOfDay, clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
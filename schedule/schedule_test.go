@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// weekdays is open 09:00-13:00 and 14:00-18:00 on weekdays in Almaty, which
+// is five hours ahead of UTC, and closed on 2024-05-09.
+var weekdays = &Schedule{
+	TimeZone: "Asia/Almaty",
+	Holidays: []string{"2024-05-09"},
+}
+
+func init() {
+	for day := time.Monday; day <= time.Friday; day++ {
+		weekdays.Weekly = append(weekdays.Weekly,
+			Hours{Day: day, Open: "09:00", Close: "13:00"},
+			Hours{Day: day, Open: "14:00", Close: "18:00"})
+	}
+}
+
+func TestIsOpen(t *testing.T) {
+	require.NoError(t, weekdays.Validate())
+	loc, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		at   time.Time
+		open bool
+	}{
+		"opening":        {time.Date(2024, 5, 6, 9, 0, 0, 0, loc), true},
+		"lunch":          {time.Date(2024, 5, 6, 13, 30, 0, 0, loc), false},
+		"closing":        {time.Date(2024, 5, 6, 18, 0, 0, 0, loc), false},
+		"night":          {time.Date(2024, 5, 7, 3, 0, 0, 0, loc), false},
+		"weekend":        {time.Date(2024, 5, 11, 10, 0, 0, 0, loc), false},
+		"holiday":        {time.Date(2024, 5, 9, 10, 0, 0, 0, loc), false},
+		"other timezone": {time.Date(2024, 5, 6, 5, 0, 0, 0, time.UTC), true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.open, weekdays.IsOpen(test.at))
+		})
+	}
+}
+
+func TestNextOpening(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		at, next time.Time
+	}{
+		"open":           {time.Date(2024, 5, 6, 10, 0, 0, 0, loc), time.Date(2024, 5, 6, 10, 0, 0, 0, loc)},
+		"lunch":          {time.Date(2024, 5, 6, 13, 30, 0, 0, loc), time.Date(2024, 5, 6, 14, 0, 0, 0, loc)},
+		"evening":        {time.Date(2024, 5, 6, 20, 0, 0, 0, loc), time.Date(2024, 5, 7, 9, 0, 0, 0, loc)},
+		"before holiday": {time.Date(2024, 5, 8, 19, 0, 0, 0, loc), time.Date(2024, 5, 10, 9, 0, 0, 0, loc)},
+		"weekend":        {time.Date(2024, 5, 11, 10, 0, 0, 0, loc), time.Date(2024, 5, 13, 9, 0, 0, 0, loc)},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			next, ok := weekdays.NextOpening(test.at)
+			require.True(t, ok)
+			assert.True(t, test.next.Equal(next), "got %v", next)
+		})
+	}
+
+	_, ok := (&Schedule{}).NextOpening(time.Now())
+	assert.False(t, ok, "a schedule without hours never opens")
+}
+
+func TestMidnight(t *testing.T) {
+	late := &Schedule{Weekly: []Hours{{Day: time.Friday, Open: "20:00", Close: "24:00"}}}
+	require.NoError(t, late.Validate())
+	assert.True(t, late.IsOpen(time.Date(2024, 5, 10, 23, 59, 0, 0, time.UTC)))
+	assert.False(t, late.IsOpen(time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestValidate(t *testing.T) {
+	for name, s := range map[string]*Schedule{
+		"time zone": {TimeZone: "Mars/Olympus"},
+		"weekday":   {Weekly: []Hours{{Day: 7, Open: "09:00", Close: "18:00"}}},
+		"format":    {Weekly: []Hours{{Day: time.Monday, Open: "9am", Close: "18:00"}}},
+		"order":     {Weekly: []Hours{{Day: time.Monday, Open: "18:00", Close: "09:00"}}},
+		"holiday":   {Holidays: []string{"09.05.2024"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, s.Validate())
+		})
+	}
+}